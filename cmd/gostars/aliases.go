@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Subcommand
+// ----------------------------------------------------------------------------
+
+// RunAliases is the "aliases" subcommand. Without arguments, it lists the URL
+// aliases of the user followed by the built-in ones. With the URLs, it prints
+// what they are resolved to and by which alias.
+func RunAliases(args []string) int {
+	cmd := GetCommand("aliases")
+	opts := new(Options)
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+
+	flags.StringVar(&opts.Aliases, "aliases", "", "path of the URL aliases file in YAML or JSON "+
+		"(default: aliases.yaml or aliases.json in the config dir if exists)")
+	flags.BoolVar(&opts.Verbose, "verbose", false, "print the progress to STDERR")
+
+	setUsage(flags, cmd)
+
+	if exitCode, ok := parseFlags(flags, args); !ok {
+		return exitCode
+	}
+
+	user, path, err := opts.LoadAliases()
+	if err != nil {
+		return PrintUsageError(flags, err)
+	}
+
+	if flags.NArg() > 0 {
+		WriteAliasResolutions(os.Stdout, user.Merge(gostars.DefaultAliasRegistry()), flags.Args())
+
+		return ExitOK
+	}
+
+	if err := WriteAliases(os.Stdout, user, path); err != nil {
+		return PrintError(err)
+	}
+
+	return ExitOK
+}
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// WriteAliases writes the aliases of the user loaded from path followed by the
+// built-in ones as a table.
+func WriteAliases(w io.Writer, user *gostars.AliasRegistry, path string) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, strings.Join([]string{"Source", "Match", "From", "To"}, "\t"))
+
+	for _, alias := range user.Aliases {
+		fmt.Fprintln(writer, strings.Join([]string{path, alias.Match, alias.From, alias.To}, "\t"))
+	}
+
+	for _, alias := range gostars.DefaultAliasRegistry().Aliases {
+		fmt.Fprintln(writer, strings.Join([]string{"built-in", alias.Match, alias.From, alias.To}, "\t"))
+	}
+
+	return errors.Wrap(writer.Flush(), "failed to write output")
+}
+
+// WriteAliasResolutions writes what the URLs are resolved to by the registry.
+// Such as:
+//
+//	https://joe-bot.net/
+//	  -> https://github.com/go-joe/joe (exact: https://joe-bot.net/)
+func WriteAliasResolutions(w io.Writer, registry *gostars.AliasRegistry, urls []string) {
+	for _, urlOrigin := range urls {
+		fmt.Fprintln(w, urlOrigin)
+
+		resolved, alias, ok := registry.Resolve(urlOrigin)
+		if !ok {
+			fmt.Fprintln(w, "  -> no alias matched")
+
+			continue
+		}
+
+		fmt.Fprintf(w, "  -> %s (%s: %s)\n", resolved, alias.Match, alias.From)
+	}
+}
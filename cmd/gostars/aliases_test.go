@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenizh/go-capturer"
+)
+
+// ----------------------------------------------------------------------------
+//  Tests
+// ----------------------------------------------------------------------------
+
+func TestRunAliases(t *testing.T) {
+	pathAliases := filepath.Join(t.TempDir(), "aliases.yaml")
+
+	require.NoError(t, os.WriteFile(pathAliases, []byte(`
+aliases:
+  - from: https://go.example.com/
+    to: https://github.com/example/
+    match: prefix
+`), 0o600))
+
+	// List
+	var exitCode int
+
+	out := capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"aliases", "--aliases", pathAliases})
+	})
+
+	assert.Equal(t, ExitOK, exitCode)
+	assert.Contains(t, out, pathAliases+"  prefix  https://go.example.com/")
+	assert.Contains(t, out, "built-in")
+
+	// Test
+	out = capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"aliases", "--aliases", pathAliases, "https://go.example.com/foo", "https://joe-bot.net/",
+			"https://unknown.example.com/"})
+	})
+
+	assert.Equal(t, ExitOK, exitCode)
+	assert.Equal(t, `https://go.example.com/foo
+  -> https://github.com/example/foo (prefix: https://go.example.com/)
+https://joe-bot.net/
+  -> https://github.com/go-joe/joe (exact: https://joe-bot.net/)
+https://unknown.example.com/
+  -> no alias matched
+`, out)
+
+	// Malformed file
+	require.NoError(t, os.WriteFile(pathAliases, []byte("aliases: [{from: a, to: b, match: glob}]"), 0o600))
+
+	out = capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"aliases", "--aliases", pathAliases})
+	})
+
+	assert.Equal(t, ExitUsage, exitCode)
+	assert.Contains(t, out, "unknown match type")
+}
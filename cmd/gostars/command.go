@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+)
+
+// Version info of the app. They are set via ldflags on release build.
+var (
+	version = ""
+	commit  = ""
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Command
+// ----------------------------------------------------------------------------
+
+// Command is a subcommand of the app.
+type Command struct {
+	Name    string                  // Name of the subcommand
+	Summary string                  // One line description of the subcommand
+	Args    string                  // Usage of the arguments
+	Run     func(args []string) int // Runs the subcommand and returns the exit code
+}
+
+// Commands is the list of the available subcommands in the order of help.
+var Commands []*Command
+
+func init() {
+	Commands = []*Command{
+		{
+			Name:    "score",
+			Summary: "Score the packages and print their attraction gravity.",
+			Args:    "<package name> [...<package name>]",
+			Run:     RunScore,
+		},
+		{
+			Name:    "compare",
+			Summary: "Compare the packages in a table ranked by gravity.",
+			Args:    "<package name> [...<package name>]",
+			Run:     RunCompare,
+		},
+		{
+			Name:    "deps",
+			Summary: "Score the dependencies in go.mod and flag the low-gravity ones.",
+			Args:    "[path to go.mod or its directory (default: ./go.mod)]",
+			Run:     RunDeps,
+		},
+		{
+			Name:    "category",
+			Summary: "Rank all the packages in a category of the awesome-go list.",
+			Args:    "<category name>",
+			Run:     RunCategory,
+		},
+		{
+			Name:    "history",
+			Summary: "Show the trend of gravity from the recorded scores.",
+			Args:    "<package name> [...<package name>]",
+			Run:     RunHistory,
+		},
+		{
+			Name:    "aliases",
+			Summary: "List the URL aliases of the repositories or test them with the URLs.",
+			Args:    "[...<URL>]",
+			Run:     RunAliases,
+		},
+		{
+			Name:    "version",
+			Summary: "Print the version of the app.",
+			Run:     RunVersion,
+		},
+	}
+}
+
+// GetCommand returns the subcommand of the name. It returns nil if not found.
+func GetCommand(name string) *Command {
+	for _, cmd := range Commands {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Subcommands
+// ----------------------------------------------------------------------------
+
+// RunScore is the "score" subcommand. It scores the packages in args and prints
+// them in the output format. (Default: text)
+func RunScore(args []string) int {
+	return scorePackages(GetCommand("score"), args, "text")
+}
+
+// RunCompare is the "compare" subcommand. It is the same as "score" but the
+// default output format is "table".
+func RunCompare(args []string) int {
+	return scorePackages(GetCommand("compare"), args, "table")
+}
+
+// RunCategory is the "category" subcommand. It downloads the awesome-go list
+// and ranks the packages in the category by gravity. With --list, it prints
+// the available category names instead.
+func RunCategory(args []string) int {
+	opts := new(Options)
+	flags := NewFlagSet(GetCommand("category"), opts, "table")
+	list := flags.Bool("list", false, "print the category names of the awesome-go list")
+
+	if exitCode, ok := parseFlags(flags, args); !ok {
+		return exitCode
+	}
+
+	if *list {
+		return printCategories(opts)
+	}
+
+	if flags.NArg() == 0 {
+		return PrintUsageError(flags, errors.New("missing category name"))
+	}
+
+	// Allow the category name without quotes. Such as: gostars category Actor Model
+	category := strings.Join(flags.Args(), " ")
+
+	return runScoring(flags, opts, scoreList(opts, func(ctx context.Context, client *gostars.Client) ([]string, error) {
+		opts.Verbosef("downloading awesome-go list from %s", gostars.URLAwesomeGo)
+
+		awesome, err := client.NewAwesomeGo(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := awesome.Entries(category)
+		if len(entries) == 0 {
+			return nil, errors.Errorf("no package found in category %q. Run \"gostars category --list\" for the categories",
+				category)
+		}
+
+		namePkgs := make([]string, 0, len(entries))
+
+		for _, entry := range entries {
+			namePkgs = append(namePkgs, entry.PackageName())
+		}
+
+		return namePkgs, nil
+	}), nil)
+}
+
+// printCategories prints the category names of the awesome-go list with the
+// number of entries.
+func printCategories(opts *Options) int {
+	ctx, cancel := NewContext(opts.Timeout)
+	defer cancel()
+
+	client := NewClient()
+	client.Cache = opts.NewCache()
+
+	awesome, err := client.NewAwesomeGo(ctx)
+	if err != nil {
+		return getExitCodeContext(ctx, PrintError(err))
+	}
+
+	for _, category := range awesome.Categories() {
+		fmt.Printf("%s (%d)\n", category, len(awesome.Entries(category)))
+	}
+
+	return ExitOK
+}
+
+// RunDeps is the "deps" subcommand. It scores the required modules in go.mod
+// and reports the modules with lower gravity than --min-gravity to STDERR.
+func RunDeps(args []string) int {
+	opts := new(Options)
+	flags := NewFlagSet(GetCommand("deps"), opts, "table")
+	skipIndirect := flags.Bool("skip-indirect", false, "skip the modules marked as \"// indirect\"")
+	minGravity := flags.Int("min-gravity", 100, "modules with lower gravity than this are flagged as low-gravity")
+
+	if exitCode, ok := parseFlags(flags, args); !ok {
+		return exitCode
+	}
+
+	if flags.NArg() > 1 {
+		return PrintUsageError(flags, errors.New("too many arguments"))
+	}
+
+	pathGoMod := flags.Arg(0)
+
+	switch info, err := os.Stat(pathGoMod); {
+	case pathGoMod == "":
+		pathGoMod = "go.mod"
+	case err == nil && info.IsDir():
+		pathGoMod = filepath.Join(pathGoMod, "go.mod")
+	}
+
+	goMod, err := os.ReadFile(pathGoMod)
+	if err != nil {
+		return PrintError(err)
+	}
+
+	return runScoring(flags, opts, func(ctx context.Context, client *gostars.Client) ([]*gostars.Score, error) {
+		opts.Verbosef("scoring the modules in %s with concurrency %d", pathGoMod, opts.Concurrency)
+
+		scores, err := client.ScoreGoMod(ctx, goMod, !*skipIndirect)
+		if err == nil && len(scores) == 0 {
+			opts.Verbosef("no module to score in %s", pathGoMod)
+		}
+
+		return scores, err
+	}, func(scores []*gostars.Score) {
+		if !opts.Quiet {
+			PrintLowGravity(os.Stderr, scores, *minGravity)
+		}
+	})
+}
+
+// RunVersion is the "version" subcommand.
+func RunVersion(_ []string) int {
+	fmt.Println("gostars version", GetVersion())
+
+	return ExitOK
+}
+
+// GetVersion returns the version of the app. If the version was not set on
+// build, it returns the version of the module from the build info.
+func GetVersion() string {
+	ver := version
+
+	if ver == "" {
+		ver = "(devel)"
+
+		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+			ver = info.Main.Version
+		}
+	}
+
+	if commit != "" {
+		ver += " (" + commit + ")"
+	}
+
+	return ver
+}
+
+// scorePackages is the common process of the subcommands that score the
+// packages given as the arguments.
+func scorePackages(cmd *Command, args []string, format string) int {
+	opts := new(Options)
+	flags := NewFlagSet(cmd, opts, format)
+
+	if exitCode, ok := parseFlags(flags, args); !ok {
+		return exitCode
+	}
+
+	if flags.NArg() == 0 {
+		return PrintUsageError(flags, errors.New("missing package name"))
+	}
+
+	return runScoring(flags, opts, scoreList(opts, listOf(flags.Args())), nil)
+}
+
+// ListPkgs returns the names of the packages to score.
+type ListPkgs func(ctx context.Context, client *gostars.Client) ([]string, error)
+
+// listOf returns a ListPkgs that returns namePkgs as is.
+func listOf(namePkgs []string) ListPkgs {
+	return func(context.Context, *gostars.Client) ([]string, error) {
+		return namePkgs, nil
+	}
+}
+
+// ScorePkgs returns the scores of the packages to output.
+type ScorePkgs func(ctx context.Context, client *gostars.Client) ([]*gostars.Score, error)
+
+// scoreList returns a ScorePkgs that scores the packages given by listPkgs with
+// ScoreAll.
+func scoreList(opts *Options, listPkgs ListPkgs) ScorePkgs {
+	return func(ctx context.Context, client *gostars.Client) ([]*gostars.Score, error) {
+		namePkgs, err := listPkgs(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Verbosef("scoring %d package(s) with concurrency %d", len(namePkgs), opts.Concurrency)
+
+		return client.ScoreAll(ctx, namePkgs), nil
+	}
+}
+
+// runScoring scores the packages with scorePkgs and writes the output as the
+// options. Then calls report with the scores if not nil. It returns the exit
+// code. Nothing is written if there is no package to score.
+func runScoring(flags *flag.FlagSet, opts *Options, scorePkgs ScorePkgs, report func([]*gostars.Score)) int {
+	if err := opts.Normalize(); err != nil {
+		return PrintUsageError(flags, err)
+	}
+
+	model, err := opts.NewGravityModel()
+	if err != nil {
+		return PrintUsageError(flags, err)
+	}
+
+	aliases, err := opts.NewAliasRegistry()
+	if err != nil {
+		return PrintUsageError(flags, err)
+	}
+
+	ctx, cancel := NewContext(opts.Timeout)
+	defer cancel()
+
+	client := NewClient()
+	opts.SetupToken(client)
+	client.Concurrency = opts.Concurrency
+	client.Cache = opts.NewCache()
+	client.GravityModel = model
+	client.Aliases = aliases
+	client.Activity = opts.Activity
+	client.ReportCard = opts.ReportCard
+	client.Coverage = opts.Coverage
+	client.Health = opts.Health
+	client.Level = opts.Level
+
+	scores, err := scorePkgs(ctx, client)
+	if err != nil {
+		return getExitCodeContext(ctx, PrintError(err))
+	}
+
+	if len(scores) == 0 {
+		return ExitOK
+	}
+
+	opts.RecordHistory(scores)
+
+	if opts.Sort != "" {
+		if err := SortScores(scores, opts.Sort, opts.Order == "asc"); err != nil {
+			return PrintError(err)
+		}
+	}
+
+	if !opts.Explain {
+		for _, score := range scores {
+			score.Breakdown = nil
+		}
+	}
+
+	if err := WriteOutput(os.Stdout, opts.Format, scores); err != nil {
+		return PrintError(err)
+	}
+
+	// Table formats have no room for the breakdown. Print it after the table
+	if opts.Explain && IsTableFormat(opts.Format) {
+		if err := WriteBreakdowns(os.Stdout, scores); err != nil {
+			return PrintError(err)
+		}
+	}
+
+	if report != nil {
+		report(scores)
+	}
+
+	if !opts.Quiet {
+		PrintSummary(os.Stderr, scores)
+	}
+
+	return getExitCodeContext(ctx, GetExitCode(scores))
+}
+
+// parseFlags parses args with flags. If it should not continue, it returns the
+// exit code and false.
+func parseFlags(flags *flag.FlagSet, args []string) (int, bool) {
+	err := flags.Parse(args)
+
+	switch {
+	case err == nil:
+		return ExitOK, true
+	case errors.Is(err, flag.ErrHelp):
+		return ExitOK, false
+	default:
+		// The flag package already printed the error and the usage
+		return ExitUsage, false
+	}
+}
+
+// getExitCodeContext returns the exit code as is if the run succeeded. If the
+// run failed because it was interrupted or timed out, it returns the according
+// exit code instead.
+func getExitCodeContext(ctx context.Context, exitCode int) int {
+	if exitCode == ExitOK {
+		return ExitOK
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return ExitTimeout
+	case errors.Is(ctx.Err(), context.Canceled):
+		return ExitInterrupted
+	default:
+		return exitCode
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenizh/go-capturer"
+)
+
+// ----------------------------------------------------------------------------
+//  Tests
+// ----------------------------------------------------------------------------
+
+func TestRun_command_help(t *testing.T) {
+	var exitCode int
+
+	out := capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"compare", "--help"})
+	})
+
+	assert.Equal(t, ExitOK, exitCode)
+	assert.Contains(t, out, "Compare the packages in a table ranked by gravity.")
+	assert.Contains(t, out, "gostars compare [options] <package name>")
+	assert.Contains(t, out, "-token")
+}
+
+func TestRun_version(t *testing.T) {
+	oldVersion, oldCommit := version, commit
+	defer func() {
+		version, commit = oldVersion, oldCommit
+	}()
+
+	version, commit = "v1.2.3", "abcdef0"
+
+	var exitCode int
+
+	out := capturer.CaptureStdout(func() {
+		exitCode = Run([]string{"version"})
+	})
+
+	assert.Equal(t, ExitOK, exitCode)
+	assert.Equal(t, "gostars version v1.2.3 (abcdef0)\n", out)
+}
+
+func TestRunDeps(t *testing.T) {
+	dirTemp := t.TempDir()
+	pathGoMod := filepath.Join(dirTemp, "go.mod")
+
+	// Missing go.mod
+	var exitCode int
+
+	out := capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"deps", dirTemp})
+	})
+
+	assert.Equal(t, ExitFailure, exitCode)
+	assert.Contains(t, out, "Error:")
+
+	// Malformed go.mod
+	require.NoError(t, os.WriteFile(pathGoMod, []byte("require github.com/foo/bar"), 0o600))
+
+	out = capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"deps", pathGoMod})
+	})
+
+	assert.Equal(t, ExitFailure, exitCode)
+	assert.Contains(t, out, "invalid go.mod: go.mod:1")
+
+	// All modules are indirect
+	require.NoError(t, os.WriteFile(pathGoMod, []byte("require github.com/foo/bar v1.0.0 // indirect"), 0o600))
+
+	out = capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"deps", "--skip-indirect", "--verbose", dirTemp})
+	})
+
+	assert.Equal(t, ExitOK, exitCode)
+	assert.Contains(t, out, "no module to score in "+pathGoMod)
+
+	// Too many args
+	out = capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"deps", pathGoMod, pathGoMod})
+	})
+
+	assert.Equal(t, ExitUsage, exitCode)
+	assert.Contains(t, out, "too many arguments")
+}
+
+func TestRunCategory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "## JSON\n\n* [gjson](https://github.com/tidwall/gjson) - Get a JSON value.\n")
+	}))
+	defer server.Close()
+
+	oldURLAwesomeGo := gostars.URLAwesomeGo
+	defer func() {
+		gostars.URLAwesomeGo = oldURLAwesomeGo
+	}()
+
+	gostars.URLAwesomeGo = server.URL + "/README.md"
+
+	// List categories
+	var exitCode int
+
+	out := capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"category", "--list"})
+	})
+
+	assert.Equal(t, ExitOK, exitCode)
+	assert.Equal(t, "JSON (1)\n", out)
+
+	// Unknown category
+	out = capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"category", "Unknown", "Category"})
+	})
+
+	assert.Equal(t, ExitFailure, exitCode)
+	assert.Contains(t, out, `no package found in category "Unknown Category"`)
+
+	// Missing category name
+	out = capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"category"})
+	})
+
+	assert.Equal(t, ExitUsage, exitCode)
+	assert.Contains(t, out, "missing category name")
+}
+
+func TestRunCategory_timeout(t *testing.T) {
+	// The download of the list does not finish until the deadline
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	oldURLAwesomeGo := gostars.URLAwesomeGo
+	defer func() {
+		gostars.URLAwesomeGo = oldURLAwesomeGo
+	}()
+
+	gostars.URLAwesomeGo = server.URL + "/README.md"
+
+	mockNewClient(t, newFakeClient(t))
+
+	for _, args := range [][]string{
+		{"category", "--timeout", "50ms", "JSON"},
+		{"category", "--timeout", "50ms", "--list"},
+	} {
+		var exitCode int
+
+		out := capturer.CaptureOutput(func() {
+			exitCode = Run(args)
+		})
+
+		assert.Equal(t, ExitTimeout, exitCode, "it should exit with timeout if the list was not downloaded in time: %v", args)
+		assert.Contains(t, out, "context deadline exceeded", args)
+	}
+}
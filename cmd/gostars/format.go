@@ -0,0 +1,427 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Record
+// ----------------------------------------------------------------------------
+
+// Record is the schema of a scored package in the machine-readable output
+// formats (json, ndjson, csv and tsv).
+//
+// The field names are the JSON keys and the CSV/TSV header. On error, only the
+// "package" and "error" fields are set and the numbers are 0.
+type Record struct {
+	Package     string `json:"package"`         // Name of the package given to score
+	Repository  string `json:"repository"`      // URL of the repository
+	Description string `json:"description"`     // Description of the repository
+	Stars       int    `json:"stars"`           // Number of stars of the repository
+	Forks       int    `json:"forks"`           // Number of forks of the repository
+	Followers   int    `json:"followers"`       // Number of people watching the repository
+	ImportedBy  int    `json:"imported_by"`     // Number of packages that imports the package at the level
+	Gravity     int    `json:"gravity"`         // Attraction gravity of the package
+	Error       string `json:"error,omitempty"` // Error message if failed to score
+
+	// Module path, directory in the repository and the level of ImportedBy. Not in CSV/TSV
+	Module string `json:"module,omitempty"`
+	Subdir string `json:"subdir,omitempty"`
+	Level  string `json:"level,omitempty"`
+
+	// Breakdown of the gravity. Only with --explain and not in CSV/TSV
+	Breakdown *gostars.GravityBreakdown `json:"breakdown,omitempty"`
+
+	// Activity of the repository. Only if fetched and not in CSV/TSV
+	Activity *gostars.RepoActivity `json:"activity,omitempty"`
+
+	// Health of the repository. Only if fetched and not in CSV/TSV
+	Health *gostars.RepoHealth `json:"health,omitempty"`
+
+	// Go Report Card of the repository. Only if fetched and not in CSV/TSV
+	ReportCard *gostars.ReportCardInfo `json:"report_card,omitempty"`
+
+	// Code coverage of the repository. Only if fetched and not in CSV/TSV
+	Coverage *gostars.CoverageInfo `json:"coverage,omitempty"`
+
+	// Errors of the optional services that did not fail the score
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// NewRecord returns the Record of the score.
+func NewRecord(score *gostars.Score) Record {
+	if score.Err != nil {
+		return Record{
+			Package: score.Name,
+			Error:   score.Err.Error(),
+		}
+	}
+
+	return Record{
+		Package:     score.Name,
+		Repository:  score.Pkg.Repository,
+		Description: score.Repo.Description,
+		Stars:       score.Repo.Stars,
+		Forks:       score.Repo.Forks,
+		Followers:   score.Repo.Followers,
+		ImportedBy:  score.Pkg.GetImportedBy(score.Level),
+		Gravity:     score.Gravity,
+		Module:      score.Pkg.Module,
+		Subdir:      score.Pkg.Subdir,
+		Level:       score.Level,
+		Breakdown:   score.Breakdown,
+		Activity:    score.Repo.Activity,
+		Health:      score.Repo.Health,
+		ReportCard:  score.ReportCard,
+		Coverage:    score.Coverage,
+		Warnings:    score.Warnings,
+	}
+}
+
+// Columns returns the values of the record in the same order as the header of
+// CSV/TSV.
+func (r Record) Columns() []string {
+	return []string{
+		r.Package,
+		r.Repository,
+		r.Description,
+		strconv.Itoa(r.Stars),
+		strconv.Itoa(r.Forks),
+		strconv.Itoa(r.Followers),
+		strconv.Itoa(r.ImportedBy),
+		strconv.Itoa(r.Gravity),
+		r.Error,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Output Formats
+// ----------------------------------------------------------------------------
+
+// csvHeader is the header row of CSV/TSV. The order must match Record.Columns.
+var csvHeader = []string{
+	"package", "repository", "description", "stars", "forks", "followers", "imported_by", "gravity", "error",
+}
+
+// writers is the list of output writers per format name.
+var writers = map[string]func(w io.Writer, scores []*gostars.Score) error{
+	"text":   WriteText,
+	"json":   WriteJSON,
+	"ndjson": WriteNDJSON,
+	"csv": func(w io.Writer, scores []*gostars.Score) error {
+		return WriteCSV(w, scores, ',')
+	},
+	"tsv": func(w io.Writer, scores []*gostars.Score) error {
+		return WriteCSV(w, scores, '\t')
+	},
+	"table":    WriteTable,
+	"markdown": WriteMarkdown,
+}
+
+// IsTableFormat returns true if the format is a comparison table. Which the
+// scores are sorted by gravity by default.
+func IsTableFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "table", "markdown":
+		return true
+	default:
+		return false
+	}
+}
+
+// ListFormats returns the sorted names of the available output formats.
+func ListFormats() []string {
+	names := make([]string, 0, len(writers))
+
+	for name := range writers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ValidateFormat returns an error if the output format is not available.
+func ValidateFormat(format string) error {
+	if _, ok := writers[strings.ToLower(format)]; !ok {
+		return errors.Errorf("unknown output format: %q (available: %s)",
+			format, strings.Join(ListFormats(), ", "))
+	}
+
+	return nil
+}
+
+// WriteOutput writes the scores to w in the given format.
+func WriteOutput(w io.Writer, format string, scores []*gostars.Score) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+
+	return writers[strings.ToLower(format)](w, scores)
+}
+
+// WriteText writes the scores as a human-readable bullet list.
+func WriteText(w io.Writer, scores []*gostars.Score) error {
+	for _, score := range scores {
+		var entry string
+
+		if score.Err != nil {
+			entry = FormatError(score)
+		} else {
+			entry = FormatInfo(score)
+
+			if score.Repo.Activity != nil {
+				entry += "\n" + FormatActivity(score.Repo)
+			}
+
+			if score.Repo.Health != nil {
+				entry += "\n" + FormatHealth(score.Repo.Health)
+			}
+
+			if score.ReportCard != nil {
+				entry += "\n" + FormatReportCard(score.ReportCard)
+			}
+
+			if score.Coverage != nil {
+				entry += "\n" + FormatCoverage(score.Coverage)
+			}
+
+			if score.Breakdown != nil {
+				entry += "\n" + FormatBreakdown(score.Breakdown)
+			}
+		}
+
+		if _, err := fmt.Fprintln(w, entry); err != nil {
+			return errors.Wrap(err, "failed to write output")
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes the scores as a JSON array of Record.
+func WriteJSON(w io.Writer, scores []*gostars.Score) error {
+	records := make([]Record, 0, len(scores))
+
+	for _, score := range scores {
+		records = append(records, NewRecord(score))
+	}
+
+	out, err := gostars.PrettyFormatJSON(records)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, out)
+
+	return errors.Wrap(err, "failed to write output")
+}
+
+// WriteNDJSON writes the scores as a Record in JSON per line.
+func WriteNDJSON(w io.Writer, scores []*gostars.Score) error {
+	encoder := json.NewEncoder(w)
+
+	for _, score := range scores {
+		if err := encoder.Encode(NewRecord(score)); err != nil {
+			return errors.Wrap(err, "failed to write output")
+		}
+	}
+
+	return nil
+}
+
+// WriteCSV writes the scores as CSV with the header row. The delimiter is given
+// by comma. Such as ',' for CSV and '\t' for TSV.
+func WriteCSV(w io.Writer, scores []*gostars.Score, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	if err := writer.Write(csvHeader); err != nil {
+		return errors.Wrap(err, "failed to write header")
+	}
+
+	for _, score := range scores {
+		if err := writer.Write(NewRecord(score).Columns()); err != nil {
+			return errors.Wrap(err, "failed to write record")
+		}
+	}
+
+	writer.Flush()
+
+	return errors.Wrap(writer.Error(), "failed to write output")
+}
+
+// ----------------------------------------------------------------------------
+//  Activity
+// ----------------------------------------------------------------------------
+
+// FormatActivity returns the activity of the repository to follow FormatInfo.
+func FormatActivity(repo *gostars.RepoInfo) string {
+	activity := repo.Activity
+	indent := "    "
+	items := map[string]interface{}{
+		indent + "1. Last Push":      formatDate(repo.LastPush),
+		indent + "2. Commits (90d)":  activity.Commits90d,
+		indent + "3. Code Frequency": fmt.Sprintf("%.1f lines/week", activity.AverageCodeFrequency()),
+		indent + "4. Releases":       activity.Releases,
+		indent + "5. Last Release":   formatDate(activity.LastRelease),
+	}
+
+	if activity.Pending {
+		items[indent+"2. Commits (90d)"] = "pending (computing by GitHub)"
+		items[indent+"3. Code Frequency"] = "pending (computing by GitHub)"
+	}
+
+	// SprintStringMap trims the indent of the first line
+	return "  Activity:\n  " + SprintStringMap(items)
+}
+
+// formatDate returns the date of t with the days ago. Such as "2022-01-02 (65
+// days ago)". It returns "-" if t is zero.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s (%d days ago)", t.Local().Format("2006-01-02"), int(time.Since(t).Hours()/24))
+}
+
+// ----------------------------------------------------------------------------
+//  Health
+// ----------------------------------------------------------------------------
+
+// FormatHealth returns the health of the issues and pull requests to follow
+// FormatInfo. The response times are the medians in the window.
+func FormatHealth(health *gostars.RepoHealth) string {
+	issues, pulls := health.Issues, health.PullRequests
+	indent := "    "
+	items := map[string]interface{}{
+		indent + "1. Issues":         fmt.Sprintf("%d open, %d closed", health.OpenIssues, health.ClosedIssues),
+		indent + "2. Stale Issues":   fmt.Sprintf("%d (%.1f%% of open)", health.StaleIssues, health.StaleRatio*100),
+		indent + "3. Issue Response": formatResponse(issues.MedianFirstResponse, issues.Responded, issues.Created),
+		indent + "4. Issue Close":    formatResponse(issues.MedianClose, issues.Closed, issues.Created),
+		indent + "5. PR Response":    formatResponse(pulls.MedianFirstResponse, pulls.Responded, pulls.Created),
+		indent + "6. PR Close":       formatResponse(pulls.MedianClose, pulls.Closed, pulls.Created),
+	}
+
+	// SprintStringMap trims the indent of the first line
+	return fmt.Sprintf("  Health: (last %d days)\n  ", health.WindowDays) + SprintStringMap(items)
+}
+
+// formatResponse returns the median time with the number of the items counted.
+// Such as "4h (2 of 3)". It returns "-" if no item was counted.
+func formatResponse(median time.Duration, count, total int) string {
+	if count == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s (%d of %d)", formatDuration(median), count, total)
+}
+
+// formatDuration returns the duration in the largest unit of minutes, hours and
+// days. Such as "45m", "4h" and "2.5d".
+func formatDuration(duration time.Duration) string {
+	switch {
+	case duration < time.Hour:
+		return fmt.Sprintf("%.0fm", duration.Minutes())
+	case duration < 48*time.Hour:
+		return fmt.Sprintf("%.0fh", duration.Hours())
+	default:
+		return fmt.Sprintf("%.1fd", duration.Hours()/24)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Report Card
+// ----------------------------------------------------------------------------
+
+// FormatReportCard returns the grade and the percentage per linter of the Go
+// Report Card to follow FormatInfo.
+func FormatReportCard(info *gostars.ReportCardInfo) string {
+	indent := "    "
+	items := map[string]interface{}{}
+
+	for _, check := range info.Checks {
+		items[indent+check.Name] = fmt.Sprintf("%.0f%%", check.Percentage*100)
+	}
+
+	result := fmt.Sprintf("  Report Card: %s (%.1f%%, %d issues in %d files)", info.Grade, info.Average*100, info.Issues, info.Files)
+
+	if len(items) > 0 {
+		// SprintStringMap trims the indent of the first line
+		result += "\n  " + SprintStringMap(items)
+	}
+
+	return result
+}
+
+// FormatCoverage returns the code coverage and its provider to follow
+// FormatInfo.
+func FormatCoverage(info *gostars.CoverageInfo) string {
+	return fmt.Sprintf("  Coverage: %.1f%% (%s)", info.Coverage, info.Provider)
+}
+
+// ----------------------------------------------------------------------------
+//  Breakdown
+// ----------------------------------------------------------------------------
+
+// breakdownHeader is the header of the breakdown table.
+var breakdownHeader = []string{"Dimension", "Value", "Weight", "Normalized", "Share"}
+
+// FormatBreakdown returns the breakdown of the gravity as an indented table to
+// follow FormatInfo. As FormatInfo, it has no trailing line break.
+func FormatBreakdown(breakdown *gostars.GravityBreakdown) string {
+	buf := new(strings.Builder)
+	indent := "    "
+
+	fmt.Fprintf(buf, "  Breakdown: (normalization: %s, scale: %g)\n",
+		breakdown.Normalization, breakdown.Scale)
+
+	writer := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, indent+strings.Join(breakdownHeader, "\t"))
+
+	for _, dim := range breakdown.Dimensions {
+		row := []string{dim.Name, "-", strconv.FormatFloat(dim.Weight, 'g', -1, 64), "-", "-"}
+
+		if dim.Available {
+			row[1] = strconv.FormatFloat(dim.Value, 'f', -1, 64)
+			row[3] = strconv.FormatFloat(dim.Normalized, 'f', 3, 64)
+			row[4] = fmt.Sprintf("%.1f%%", dim.Share*100)
+		}
+
+		fmt.Fprintln(writer, indent+strings.Join(row, "\t"))
+	}
+
+	_ = writer.Flush() // Never fails on strings.Builder
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// WriteBreakdowns writes the breakdown of each score with its package name.
+// The scores without the breakdown are skipped.
+func WriteBreakdowns(w io.Writer, scores []*gostars.Score) error {
+	for _, score := range scores {
+		if score.Breakdown == nil {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "\n- %s\n%s\n", score.Name, FormatBreakdown(score.Breakdown)); err != nil {
+			return errors.Wrap(err, "failed to write output")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Examples (Tests for golden-cases)
+// ----------------------------------------------------------------------------
+
+func ExampleWriteOutput() {
+	scores := sampleScores()
+
+	for _, format := range []string{"ndjson", "csv"} {
+		fmt.Println("Format:", format)
+
+		if err := WriteOutput(os.Stdout, format, scores); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Output:
+	// Format: ndjson
+	// {"package":"github.com/KEINOS/dev-go","repository":"https://github.com/KEINOS/dev-go","description":"Dockerfile for Go","stars":10,"forks":3,"followers":2,"imported_by":2,"gravity":10}
+	// {"package":"github.com/KEINOS/undefined","repository":"","description":"","stars":0,"forks":0,"followers":0,"imported_by":0,"gravity":0,"error":"not found"}
+	// Format: csv
+	// package,repository,description,stars,forks,followers,imported_by,gravity,error
+	// github.com/KEINOS/dev-go,https://github.com/KEINOS/dev-go,Dockerfile for Go,10,3,2,2,10,
+	// github.com/KEINOS/undefined,,,0,0,0,0,0,not found
+}
+
+// ----------------------------------------------------------------------------
+//  Tests
+// ----------------------------------------------------------------------------
+
+func TestWriteOutput_json(t *testing.T) {
+	var out strings.Builder
+
+	err := WriteOutput(&out, "JSON", sampleScores())
+	require.NoError(t, err)
+
+	var records []Record
+
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &records))
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "github.com/KEINOS/dev-go", records[0].Package)
+	assert.Equal(t, 10, records[0].Gravity)
+	assert.Empty(t, records[0].Error)
+	assert.Equal(t, "not found", records[1].Error)
+}
+
+func TestWriteOutput_tsv(t *testing.T) {
+	var out strings.Builder
+
+	err := WriteOutput(&out, "tsv", sampleScores())
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, strings.Join(csvHeader, "\t"), lines[0])
+	assert.Equal(t, "github.com/KEINOS/undefined\t\t\t0\t0\t0\t0\t0\tnot found", lines[2])
+}
+
+func TestWriteOutput_text(t *testing.T) {
+	var out strings.Builder
+
+	err := WriteOutput(&out, "text", sampleScores())
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "- dev-go\n  1. Gravity:      10")
+	assert.Contains(t, out.String(), "- github.com/KEINOS/undefined\n  Error: not found")
+}
+
+func TestWriteOutput_unknown_format(t *testing.T) {
+	var out strings.Builder
+
+	err := WriteOutput(&out, "xml", sampleScores())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown output format: "xml"`)
+	assert.Empty(t, out.String())
+}
+
+func TestWriteText_explain(t *testing.T) {
+	scores := sampleScores()
+	scores[0].Breakdown = gostars.DefaultGravityModel().Explain(scores[0])
+
+	buf := new(strings.Builder)
+
+	require.NoError(t, WriteText(buf, scores))
+
+	out := buf.String()
+
+	assert.Contains(t, out, "\n  Breakdown: (normalization: none, scale: 1)\n")
+	assert.Contains(t, out, "Dimension    Value  Weight  Normalized  Share")
+	assert.Contains(t, out, "stars        10     1       10.000      ")
+
+	// Table formats print the breakdown after the table
+	buf.Reset()
+
+	require.NoError(t, WriteBreakdowns(buf, scores))
+	assert.Contains(t, buf.String(), "- "+scores[0].Name+"\n  Breakdown:")
+	assert.NotContains(t, buf.String(), scores[1].Name, "failed score has no breakdown")
+
+	// JSON
+	buf.Reset()
+
+	require.NoError(t, WriteJSON(buf, scores))
+	assert.Contains(t, buf.String(), `"breakdown": {`)
+}
+
+func TestWriteText_activity(t *testing.T) {
+	scores := sampleScores()
+	scores[0].Repo.Activity = &gostars.RepoActivity{
+		Releases:    7,
+		LastRelease: time.Now().AddDate(0, 0, -65),
+		Pending:     true,
+	}
+
+	buf := new(strings.Builder)
+
+	require.NoError(t, WriteText(buf, scores))
+
+	out := buf.String()
+
+	assert.Contains(t, out, "  7. ImportedBy:   2\n  Activity:\n    1. Last Push:      -\n")
+	assert.Contains(t, out, "2. Commits (90d):  pending")
+	assert.Contains(t, out, "4. Releases:       7\n")
+	assert.Contains(t, out, "(65 days ago)")
+
+	// JSON
+	buf.Reset()
+
+	require.NoError(t, WriteJSON(buf, scores))
+	assert.Contains(t, buf.String(), `"activity": {`)
+}
+
+func TestWriteText_report_card(t *testing.T) {
+	scores := sampleScores()
+	scores[0].ReportCard = &gostars.ReportCardInfo{
+		Grade:   "A+",
+		Average: 0.955,
+		Files:   10,
+		Issues:  1,
+		Checks: []gostars.ReportCardCheck{
+			{Name: "gofmt", Percentage: 1},
+			{Name: "golint", Percentage: 0.9},
+		},
+	}
+
+	buf := new(strings.Builder)
+
+	require.NoError(t, WriteText(buf, scores))
+
+	assert.Contains(t, buf.String(),
+		"  Report Card: A+ (95.5%, 1 issues in 10 files)\n    gofmt:  100%\n    golint: 90%\n")
+
+	// JSON
+	buf.Reset()
+
+	require.NoError(t, WriteJSON(buf, scores))
+	assert.Contains(t, buf.String(), `"report_card": {`)
+}
+
+func TestWriteText_coverage(t *testing.T) {
+	scores := sampleScores()
+	scores[0].Coverage = &gostars.CoverageInfo{Provider: gostars.ProviderCoveralls, Coverage: 70.25}
+
+	buf := new(strings.Builder)
+
+	require.NoError(t, WriteText(buf, scores))
+	assert.Contains(t, buf.String(), "  Coverage: 70.2% (coveralls)\n")
+}
+
+func TestWriteText_health(t *testing.T) {
+	scores := sampleScores()
+	scores[0].Repo.Health = &gostars.RepoHealth{
+		WindowDays:   180,
+		OpenIssues:   4,
+		ClosedIssues: 6,
+		StaleIssues:  1,
+		StaleRatio:   0.25,
+		Issues: gostars.HealthStats{
+			Created: 3, Responded: 2, Closed: 1, MedianFirstResponse: 30 * time.Minute, MedianClose: 60 * time.Hour,
+		},
+		PullRequests: gostars.HealthStats{Created: 1},
+	}
+
+	buf := new(strings.Builder)
+
+	require.NoError(t, WriteText(buf, scores))
+
+	out := buf.String()
+
+	assert.Contains(t, out, "  Health: (last 180 days)\n    1. Issues:         4 open, 6 closed\n")
+	assert.Contains(t, out, "    2. Stale Issues:   1 (25.0% of open)\n")
+	assert.Contains(t, out, "    3. Issue Response: 30m (2 of 3)\n")
+	assert.Contains(t, out, "    4. Issue Close:    2.5d (1 of 3)\n")
+	assert.Contains(t, out, "    5. PR Response:    -\n")
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+)
+
+// sparkBars are the bars of the sparkline from the lowest to the highest.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// historyHeader is the header of the history table.
+var historyHeader = []string{
+	"Date", "Gravity", "Delta", "Stars", "Forks", "Followers", "Imported By",
+}
+
+// ----------------------------------------------------------------------------
+//  Type: History
+// ----------------------------------------------------------------------------
+
+// History is the output of "history" subcommand per package.
+type History struct {
+	Name      string             `json:"name"`      // Name of the package
+	Snapshots []gostars.Snapshot `json:"snapshots"` // Recorded scores in chronological order
+	Trend     *gostars.Trend     `json:"trend"`     // Trend of the gravity
+}
+
+// ----------------------------------------------------------------------------
+//  Subcommand
+// ----------------------------------------------------------------------------
+
+// RunHistory is the "history" subcommand. It prints the recorded scores of the
+// packages with the deltas, the growth rate per month and the sparkline of the
+// gravity.
+//
+// The scores are recorded by the subcommands that score the packages unless
+// --no-history is set.
+func RunHistory(args []string) int {
+	cmd := GetCommand("history")
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	format := flags.String("format", "text", "output format. text or json")
+	limit := flags.Int("limit", 0, "number of the latest snapshots to show. 0 for all")
+
+	setUsage(flags, cmd)
+
+	if exitCode, ok := parseFlags(flags, args); !ok {
+		return exitCode
+	}
+
+	if flags.NArg() == 0 {
+		return PrintUsageError(flags, errors.New("missing package name"))
+	}
+
+	if *format != "text" && *format != "json" {
+		return PrintUsageError(flags, errors.Errorf("unknown output format: %q (available: text, json)", *format))
+	}
+
+	path, err := gostars.DefaultHistoryPath()
+	if err != nil {
+		return PrintError(err)
+	}
+
+	histories := []*History{}
+	store := gostars.NewHistory(path)
+
+	for _, namePkg := range flags.Args() {
+		snapshots, err := store.Load(namePkg)
+		if err != nil {
+			return PrintError(err)
+		}
+
+		if len(snapshots) == 0 {
+			return PrintError(errors.Errorf("no history of %s. Score the package first. Such as: gostars %s",
+				namePkg, namePkg))
+		}
+
+		if *limit > 0 && len(snapshots) > *limit {
+			snapshots = snapshots[len(snapshots)-*limit:]
+		}
+
+		trend, err := gostars.NewTrend(snapshots)
+		if err != nil {
+			return PrintError(err)
+		}
+
+		histories = append(histories, &History{Name: namePkg, Snapshots: snapshots, Trend: trend})
+	}
+
+	if *format == "json" {
+		err = WriteHistoryJSON(os.Stdout, histories)
+	} else {
+		err = WriteHistory(os.Stdout, histories)
+	}
+
+	if err != nil {
+		return PrintError(err)
+	}
+
+	return ExitOK
+}
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// Sparkline returns the values as a line of bars. Such as "▁▂▄█". The bars are
+// scaled between the minimum and the maximum of the values.
+func Sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lowest, highest := values[0], values[0]
+
+	for _, value := range values {
+		if value < lowest {
+			lowest = value
+		}
+
+		if value > highest {
+			highest = value
+		}
+	}
+
+	line := make([]rune, len(values))
+	maxBar := len(sparkBars) - 1
+
+	for i, value := range values {
+		index := 0
+		if highest > lowest {
+			index = (value - lowest) * maxBar / (highest - lowest)
+		}
+
+		line[i] = sparkBars[index]
+	}
+
+	return string(line)
+}
+
+// WriteHistory writes the histories as tables followed by the trend.
+func WriteHistory(w io.Writer, histories []*History) error {
+	for index, history := range histories {
+		if index > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintf(w, "- %s\n", history.Name)
+
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(writer, "  "+strings.Join(historyHeader, "\t"))
+
+		gravities := make([]int, len(history.Snapshots))
+
+		for i, snapshot := range history.Snapshots {
+			delta := "-"
+			if i > 0 {
+				delta = formatDelta(snapshot.Gravity - history.Snapshots[i-1].Gravity)
+			}
+
+			gravities[i] = snapshot.Gravity
+
+			fmt.Fprintln(writer, "  "+strings.Join([]string{
+				snapshot.Time.Local().Format("2006-01-02 15:04"),
+				strconv.Itoa(snapshot.Gravity),
+				delta,
+				strconv.Itoa(snapshot.Stars),
+				strconv.Itoa(snapshot.Forks),
+				strconv.Itoa(snapshot.Followers),
+				strconv.Itoa(snapshot.ImportedBy),
+			}, "\t"))
+		}
+
+		if err := writer.Flush(); err != nil {
+			return errors.Wrap(err, "failed to write output")
+		}
+
+		trend := history.Trend
+
+		fmt.Fprintf(w, "  Trend:  %s\n", Sparkline(gravities))
+		fmt.Fprintf(w, "  Delta:  %s (%d -> %d)\n", formatDelta(trend.Delta), trend.First.Gravity, trend.Last.Gravity)
+		fmt.Fprintf(w, "  Growth: %+.1f%%/month (%+.1f gravity/month)\n", trend.GrowthRate, trend.DeltaPerMonth)
+	}
+
+	return nil
+}
+
+// WriteHistoryJSON writes the histories as a JSON array.
+func WriteHistoryJSON(w io.Writer, histories []*History) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return errors.Wrap(encoder.Encode(histories), "failed to write output")
+}
+
+// formatDelta returns the delta with the sign. Such as "+10", "-5" and "0".
+func formatDelta(delta int) string {
+	if delta > 0 {
+		return "+" + strconv.Itoa(delta)
+	}
+
+	return strconv.Itoa(delta)
+}
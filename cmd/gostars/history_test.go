@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenizh/go-capturer"
+)
+
+// ----------------------------------------------------------------------------
+//  Tests
+// ----------------------------------------------------------------------------
+
+func TestRunHistory(t *testing.T) {
+	pathHistory := filepath.Join(t.TempDir(), "history.jsonl")
+
+	t.Setenv(gostars.EnvHistoryFile, pathHistory)
+
+	// No history yet
+	var exitCode int
+
+	out := capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"history", "github.com/foo/bar"})
+	})
+
+	assert.Equal(t, ExitFailure, exitCode)
+	assert.Contains(t, out, "no history of github.com/foo/bar")
+
+	// Record the scores
+	opts := &Options{}
+	score := sampleScores()[0]
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, gravity := range []int{10, 40, 20, 80} {
+		snapshot, ok := gostars.NewSnapshot(score, start.AddDate(0, i, 0))
+		require.True(t, ok)
+
+		snapshot.Gravity = gravity
+
+		require.NoError(t, gostars.NewHistory(pathHistory).Append(snapshot))
+	}
+
+	opts.RecordHistory(sampleScores()) // the failed one should be skipped
+
+	// Text
+	out = capturer.CaptureStdout(func() {
+		exitCode = Run([]string{"history", "--limit", "4", score.Name})
+	})
+
+	require.Equal(t, ExitOK, exitCode)
+	assert.Contains(t, out, "- "+score.Name)
+	assert.Contains(t, out, "Trend:  ▄▂█▁", "it should show the latest 4 snapshots")
+	assert.Contains(t, out, "Delta:  -30 (40 -> 10)")
+
+	// JSON
+	out = capturer.CaptureStdout(func() {
+		exitCode = Run([]string{"history", "--format", "json", score.Name})
+	})
+
+	require.Equal(t, ExitOK, exitCode)
+
+	var histories []History
+
+	require.NoError(t, json.Unmarshal([]byte(out), &histories))
+	require.Len(t, histories, 1)
+	assert.Len(t, histories[0].Snapshots, 5)
+	assert.Equal(t, 10, histories[0].Trend.Last.Gravity, "the recorded score should be the latest")
+
+	// Unknown format
+	out = capturer.CaptureOutput(func() {
+		exitCode = Run([]string{"history", "--format", "csv", score.Name})
+	})
+
+	assert.Equal(t, ExitUsage, exitCode)
+	assert.Contains(t, out, "unknown output format")
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", Sparkline(nil))
+	assert.Equal(t, "▁▁▁", Sparkline([]int{5, 5, 5}))
+	assert.Equal(t, "▁▂▃▄▅▆▇█", Sparkline([]int{0, 1, 2, 3, 4, 5, 6, 7}))
+	assert.Equal(t, "█▁", Sparkline([]int{100, -100}))
+}
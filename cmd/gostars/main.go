@@ -1,33 +1,72 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+)
+
+// Exit codes of the app.
+const (
+	ExitOK             = 0   // All the packages were scored
+	ExitFailure        = 1   // All the packages failed to score or other errors
+	ExitUsage          = 2   // Invalid options or arguments
+	ExitPartialFailure = 3   // Some of the packages failed to score
+	ExitTimeout        = 124 // The run did not finish within the --timeout
+	ExitInterrupted    = 130 // The run was canceled by the interrupt signal (Ctrl+C)
 )
 
 // LogFatal is a copy of log.Fatal to ease mock during test.
 var LogFatal = log.Fatal
 
+// OsExit is a copy of os.Exit to ease mock during test.
+var OsExit = os.Exit
+
+// NewClient is a copy of gostars.NewClient to ease mock during test. The
+// subcommands get their client from it.
+var NewClient = gostars.NewClient
+
 // ----------------------------------------------------------------------------
 //  Main
 // ----------------------------------------------------------------------------
 
 func main() {
-	if len(os.Args) > 1 {
-		for _, namePackage := range os.Args[1:] {
-			info, err := GetInfo(namePackage)
-			ExitOnError(err)
+	if exitCode := Run(os.Args[1:]); exitCode != ExitOK {
+		OsExit(exitCode)
+	}
+}
 
-			fmt.Println(info)
-		}
-	} else {
+// Run runs the subcommand in args and returns the exit code. If the first arg
+// is not a subcommand, it runs "score" subcommand for backward compatibility.
+func Run(args []string) int {
+	if len(args) == 0 {
+		PrintHelp()
+
+		return ExitOK
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
 		PrintHelp()
+
+		return ExitOK
 	}
+
+	if cmd := GetCommand(args[0]); cmd != nil {
+		return cmd.Run(args[1:])
+	}
+
+	return RunScore(args)
 }
 
 // ----------------------------------------------------------------------------
@@ -41,56 +80,189 @@ func ExitOnError(err error) {
 	}
 }
 
-// GetInfo returns the package information in uniformed format.
-func GetInfo(namePkg string) (string, error) {
-	var (
-		pkgInfo  *gostars.PkgInfo
-		repoInfo *gostars.RepoInfo
-		err      error
-	)
+// FormatError returns the error entry of the package failed to score.
+func FormatError(score *gostars.Score) string {
+	return fmt.Sprintf("- %s\n  Error: %v", score.Name, score.Err)
+}
+
+// GetExitCode returns the exit code according to the number of packages failed
+// to score.
+func GetExitCode(scores []*gostars.Score) int {
+	numFailed := countFailed(scores)
 
-	// Get package and repository info
-	if pkgInfo, err = gostars.NewPkgInfo(namePkg); err == nil {
-		repoInfo, err = gostars.NewRepoInfo(pkgInfo.Repository)
+	switch {
+	case numFailed == 0:
+		return ExitOK
+	case numFailed == len(scores):
+		return ExitFailure
+	default:
+		return ExitPartialFailure
 	}
+}
+
+// GetInfo returns the package information in uniformed format. The package is
+// scored with the client. Such as the one configured by the options.
+func GetInfo(client *gostars.Client, namePkg string) (string, error) {
+	return GetInfoContext(context.Background(), client, namePkg)
+}
 
+// GetInfoContext is the same as GetInfo but with a context to cancel the
+// requests.
+func GetInfoContext(ctx context.Context, client *gostars.Client, namePkg string) (string, error) {
+	score, err := client.ScorePackage(ctx, namePkg)
 	if err != nil {
 		return "", err
 	}
 
-	// Get dimension values from both package and repository info
-	stars := repoInfo.Stars
-	forks := repoInfo.Forks
-	follow := repoInfo.Followers // equivalent to watching
-	importedBy := pkgInfo.ImportedBy
-
-	// Calculate force/attractiveness from the values of dimensions
-	gravity := gostars.GetAttractionGravity(
-		stars, forks, follow, importedBy,
-	)
+	return FormatInfo(score), nil
+}
 
+// FormatInfo returns the package information of the score in uniformed format.
+func FormatInfo(score *gostars.Score) string {
 	indent := "  "
 
-	result := fmt.Sprintln("-", repoInfo.Name)
+	result := fmt.Sprintln("-", score.Repo.Name)
 
 	items := map[string]interface{}{
-		indent + "1. Gravity":      gravity,
-		indent + "2. Package Name": pkgInfo.Name,
-		indent + "3. URL":          pkgInfo.Repository,
-		indent + "4. Stars":        stars,
-		indent + "5. Forks":        forks,
-		indent + "6. Folllows":     follow,
-		indent + "7. ImportedBy":   importedBy,
+		indent + "1. Gravity":      score.Gravity,
+		indent + "2. Package Name": score.Pkg.Name,
+		indent + "3. URL":          score.Pkg.Repository,
+		indent + "4. Stars":        score.Repo.Stars,
+		indent + "5. Forks":        score.Repo.Forks,
+		indent + "6. Folllows":     score.Repo.Followers, // equivalent to watching
+		indent + "7. ImportedBy":   score.Pkg.ImportedBy,
+	}
+
+	// Only for the packages in a subdirectory with the module resolved by --level
+	if score.Pkg.Subdir != "" && score.Pkg.Module != "" {
+		items[indent+"8. Module"] = fmt.Sprintf("%s (imported by %d)", score.Pkg.Module, score.Pkg.ModuleImportedBy)
+		items[indent+"9. Repository Root"] = fmt.Sprintf("%s (imported by %d)", score.Pkg.RepoRoot, score.Pkg.RepoImportedBy)
+	}
+
+	// The gravity counts the importers of the module or the repository root
+	if score.Level != "" && score.Level != gostars.LevelPackage {
+		items[indent+"1. Gravity"] = fmt.Sprintf("%d (%s level)", score.Gravity, score.Level)
 	}
 
 	result += SprintStringMap(items)
 
-	return result, nil
+	return result
+}
+
+// NewContext returns a context that is canceled on interrupt signal (Ctrl+C).
+// If timeout is greater than 0, the context will also be canceled after the
+// timeout.
+func NewContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctxTimeout, func() {
+		cancel()
+		stop()
+	}
+}
+
+// PrintError prints the error to STDERR and returns ExitFailure.
+func PrintError(err error) int {
+	fmt.Fprintln(os.Stderr, "Error:", err)
+
+	return ExitFailure
 }
 
 // PrintHelp displays the help message.
 func PrintHelp() {
-	fmt.Println("help me")
+	fmt.Print(`gostars - Get the attraction gravity of Go packages.
+
+Usage:
+  gostars <command> [options] [arguments]
+  gostars [options] <package name> [...<package name>]  (same as "score")
+
+Commands:
+`)
+
+	for _, cmd := range Commands {
+		fmt.Printf("  %-9s %s\n", cmd.Name, cmd.Summary)
+	}
+
+	fmt.Printf(`
+Run "gostars <command> --help" for the options of each command.
+
+GitHub token:
+  The token is searched in the following order. Without a token, the requests
+  to GitHub API are limited to 60 per hour.
+    1. --token option
+    2. GITHUB_TOKEN environment variable
+    3. GH_TOKEN environment variable
+    4. hosts.yml of GitHub CLI ($GH_CONFIG_DIR or ~/.config/gh)
+    5. password of api.github.com or github.com machine in ~/.netrc ($NETRC)
+
+Exit codes:
+  %-3d  All the packages were scored.
+  %-3d  All the packages failed to score or other errors occurred.
+  %-3d  Invalid options or arguments.
+  %-3d  Some of the packages failed to score.
+  %-3d  The run did not finish within the --timeout.
+  %-3d  The run was canceled by the interrupt signal. (Ctrl+C)
+`, ExitOK, ExitFailure, ExitUsage, ExitPartialFailure, ExitTimeout, ExitInterrupted)
+}
+
+// PrintUsageError prints the error and the usage of the flags to STDERR. Then
+// returns ExitUsage.
+func PrintUsageError(flags *flag.FlagSet, err error) int {
+	fmt.Fprintln(flags.Output(), "Error:", err)
+	flags.Usage()
+
+	return ExitUsage
+}
+
+// PrintLowGravity prints the list of packages with lower gravity than
+// minGravity to w. It prints nothing if there is none.
+func PrintLowGravity(w io.Writer, scores []*gostars.Score, minGravity int) {
+	lows := []*gostars.Score{}
+
+	for _, score := range scores {
+		if score.Err == nil && score.Gravity < minGravity {
+			lows = append(lows, score)
+		}
+	}
+
+	if len(lows) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Low-gravity dependencies (gravity < %d): %d of %d\n", minGravity, len(lows), len(scores))
+
+	for _, score := range lows {
+		fmt.Fprintf(w, "  - %s: %d\n", score.Name, score.Gravity)
+	}
+}
+
+// PrintSummary prints the warnings and the list of packages failed to score to
+// w. It prints nothing if all the packages were scored without warnings.
+func PrintSummary(w io.Writer, scores []*gostars.Score) {
+	for _, score := range scores {
+		for _, warning := range score.Warnings {
+			fmt.Fprintf(w, "Warning: %s: %s\n", score.Name, warning)
+		}
+	}
+
+	numFailed := countFailed(scores)
+	if numFailed == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Failed to score %d of %d packages:\n", numFailed, len(scores))
+
+	for _, score := range scores {
+		if score.Err != nil {
+			fmt.Fprintf(w, "  - %s: %v\n", score.Name, score.Err)
+		}
+	}
 }
 
 // SprintStringMap returns a formatted string from a map input.
@@ -122,3 +294,36 @@ func SprintStringMap(input map[string]interface{}) string {
 
 	return "  " + strings.TrimSpace(result)
 }
+
+// validateOptions returns an error if any of the output options is invalid.
+func validateOptions(format, sortBy, order string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+
+	if sortBy != "" {
+		// Sorting an empty list only validates the field name
+		if err := SortScores(nil, sortBy, true); err != nil {
+			return err
+		}
+	}
+
+	switch strings.ToLower(order) {
+	case "asc", "desc":
+		return nil
+	default:
+		return errors.Errorf("unknown sort order: %q (available: asc, desc)", order)
+	}
+}
+
+func countFailed(scores []*gostars.Score) int {
+	numFailed := 0
+
+	for _, score := range scores {
+		if score.Err != nil {
+			numFailed++
+		}
+	}
+
+	return numFailed
+}
@@ -1,58 +1,162 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/KEINOS/gostars/gostars"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zenizh/go-capturer"
 )
 
+// TestMain isolates the on-disk cache and the history from the user's ones
+// during the tests.
+func TestMain(m *testing.M) {
+	dirCache, err := os.MkdirTemp("", "gostars-cache-*")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	os.Setenv(gostars.EnvCacheDir, dirCache)
+	os.Setenv(gostars.EnvHistoryFile, filepath.Join(dirCache, "history.jsonl"))
+
+	exitCode := m.Run()
+
+	os.RemoveAll(dirCache)
+	os.Exit(exitCode)
+}
+
+// newFakeClient returns a Client which requests to a mock server of pkg.go.dev
+// and GitHub API instead of the real ones. The mock knows only
+// github.com/KEINOS/Hello-Cobra.
+func newFakeClient(t *testing.T) *gostars.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/pkg/github.com/KEINOS/Hello-Cobra", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body>
+<div class="u-breakWord">github.com/foo/bar</div>
+<div class="UnitMeta-repo"><a href="https://github.com/KEINOS/Hello-Cobra">github.com/KEINOS/Hello-Cobra</a></div>
+</body></html>`)
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/Hello-Cobra", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": "Hello-Cobra", "description": "Hello world of Cobra",
+"stargazers_count": 11, "forks_count": 2, "subscribers_count": 2}`)
+	})
+
+	server := httptest.NewServer(mux)
+
+	t.Cleanup(server.Close)
+
+	client := gostars.NewClient()
+
+	client.HTTPClient = server.Client()
+	client.GitHubBaseURL = server.URL + "/api/"
+	client.PkgGoDevBaseURL = server.URL + "/pkg"
+	client.GoProxyBaseURL = server.URL + "/proxy"
+	client.RateLimiter = nil // no need to throttle the mock server
+
+	return client
+}
+
+// mockNewClient replaces NewClient with the one returns the client during the
+// test.
+func mockNewClient(t *testing.T, client *gostars.Client) {
+	t.Helper()
+
+	oldNewClient := NewClient
+
+	t.Cleanup(func() {
+		NewClient = oldNewClient
+	})
+
+	NewClient = func() *gostars.Client {
+		return client
+	}
+}
+
 // ----------------------------------------------------------------------------
 //  Examples (Tests for golden-cases)
 // ----------------------------------------------------------------------------
 
-func ExampleGetInfo() {
-	namePkg := "github.com/KEINOS/Hello-Cobra"
-
-	// The output format is as follows:
-	//   - Hello-Cobra
-	//     1. Gravity:     11
-	//     2. Package Name: github.com/KEINOS/Hello-Cobra
-	//     3. URL:          https://github.com/KEINOS/Hello-Cobra
-	//     4. Stars:        11
-	//     5. Forks:        2
-	//     6. Folllows:     2
-	//     7. ImportedBy:   0
-	infoPkg, err := GetInfo(namePkg)
-	if err != nil {
-		log.Fatal(err)
+func ExampleFormatInfo() {
+	score := &gostars.Score{
+		Name: "github.com/KEINOS/dev-go",
+		Pkg: &gostars.PkgInfo{
+			Name:       "github.com/KEINOS/dev-go",
+			Repository: "https://github.com/KEINOS/dev-go",
+			ImportedBy: 2,
+		},
+		Repo: &gostars.RepoInfo{
+			Name:      "dev-go",
+			Stars:     10,
+			Forks:     3,
+			Followers: 2,
+		},
+		Gravity: 10,
 	}
 
-	contains := true
+	fmt.Println(FormatInfo(score))
 
-	for _, contain := range []string{
-		"Hello-Cobra",
-		"Gravity:",
-		"URL:",
-		"https://github.com/KEINOS/Hello-Cobra",
-		"Stars:",
-		"Forks:",
-		"Folllows:",
-		"ImportedBy:",
-	} {
-		contains = strings.Contains(infoPkg, contain) && contains
+	// Output:
+	// - dev-go
+	//   1. Gravity:      10
+	//   2. Package Name: github.com/KEINOS/dev-go
+	//   3. URL:          https://github.com/KEINOS/dev-go
+	//   4. Stars:        10
+	//   5. Forks:        3
+	//   6. Folllows:     2
+	//   7. ImportedBy:   2
+}
+
+func ExampleFormatInfo_module() {
+	score := &gostars.Score{
+		Name: "github.com/aws/aws-sdk-go-v2/service/s3",
+		Pkg: &gostars.PkgInfo{
+			Name:             "github.com/aws/aws-sdk-go-v2/service/s3",
+			Repository:       "https://github.com/aws/aws-sdk-go-v2",
+			Module:           "github.com/aws/aws-sdk-go-v2/service/s3",
+			RepoRoot:         "github.com/aws/aws-sdk-go-v2",
+			Subdir:           "service/s3",
+			ImportedBy:       50,
+			ModuleImportedBy: 50,
+			RepoImportedBy:   300,
+		},
+		Repo: &gostars.RepoInfo{
+			Name:      "aws-sdk-go-v2",
+			Stars:     1000,
+			Forks:     300,
+			Followers: 50,
+		},
+		Level:   gostars.LevelRepo,
+		Gravity: 1650,
 	}
 
-	fmt.Printf("The output contains all: %v", contains)
+	fmt.Println(FormatInfo(score))
 
 	// Output:
-	// The output contains all: true
+	// - aws-sdk-go-v2
+	//   1. Gravity:         1650 (repo level)
+	//   2. Package Name:    github.com/aws/aws-sdk-go-v2/service/s3
+	//   3. URL:             https://github.com/aws/aws-sdk-go-v2
+	//   4. Stars:           1000
+	//   5. Forks:           300
+	//   6. Folllows:        50
+	//   7. ImportedBy:      50
+	//   8. Module:          github.com/aws/aws-sdk-go-v2/service/s3 (imported by 50)
+	//   9. Repository Root: github.com/aws/aws-sdk-go-v2 (imported by 300)
 }
 
 func ExampleSprintStringMap() {
@@ -72,6 +176,36 @@ func ExampleSprintStringMap() {
 	// thousand: 1000
 }
 
+// ----------------------------------------------------------------------------
+//  Helper Functions
+// ----------------------------------------------------------------------------
+
+// sampleScores returns the scores of a succeeded and a failed package.
+func sampleScores() []*gostars.Score {
+	return []*gostars.Score{
+		{
+			Name: "github.com/KEINOS/dev-go",
+			Pkg: &gostars.PkgInfo{
+				Name:       "github.com/KEINOS/dev-go",
+				Repository: "https://github.com/KEINOS/dev-go",
+				ImportedBy: 2,
+			},
+			Repo: &gostars.RepoInfo{
+				Name:        "dev-go",
+				Description: "Dockerfile for Go",
+				Stars:       10,
+				Forks:       3,
+				Followers:   2,
+			},
+			Gravity: 10,
+		},
+		{
+			Name: "github.com/KEINOS/undefined",
+			Err:  errors.New("not found"),
+		},
+	}
+}
+
 // ----------------------------------------------------------------------------
 //  Tests
 // ----------------------------------------------------------------------------
@@ -79,20 +213,33 @@ func ExampleSprintStringMap() {
 func Test_main_golden(t *testing.T) {
 	// Backup and defer restore
 	oldOsArgs := os.Args
+	oldOsExit := OsExit
 	defer func() {
 		os.Args = oldOsArgs
+		OsExit = oldOsExit
 	}()
 
+	mockNewClient(t, newFakeClient(t))
+
 	// Mock os.Args
 	os.Args = []string{
 		t.Name(),
+		"--no-history",
 		"github.com/KEINOS/Hello-Cobra",
 	}
 
+	// Mock OsExit and capture the exit code
+	exitCode := ExitOK
+	OsExit = func(code int) {
+		exitCode = code
+	}
+
 	out := capturer.CaptureOutput(func() {
 		main()
 	})
 
+	assert.Equal(t, ExitOK, exitCode)
+
 	for _, contain := range []string{
 		"Hello-Cobra",
 		"Gravity:",
@@ -123,7 +270,76 @@ func Test_main_help(t *testing.T) {
 		main()
 	})
 
-	assert.Contains(t, out, "help me")
+	for _, contain := range []string{
+		"Usage:",
+		"Commands:",
+		"score",
+		"compare",
+		"version",
+		"Exit codes:",
+	} {
+		assert.Contains(t, out, contain)
+	}
+}
+
+func Test_main_timeout(t *testing.T) {
+	// Backup and defer restore
+	oldOsArgs := os.Args
+	oldOsExit := OsExit
+	defer func() {
+		os.Args = oldOsArgs
+		OsExit = oldOsExit
+	}()
+
+	mockNewClient(t, newFakeClient(t))
+
+	// Mock os.Args
+	os.Args = []string{
+		t.Name(),
+		"--timeout", "1ns",
+		"github.com/KEINOS/Hello-Cobra",
+		"github.com/KEINOS/dev-go",
+	}
+
+	// Mock OsExit and capture the exit code
+	exitCode := ExitOK
+	OsExit = func(code int) {
+		exitCode = code
+	}
+
+	out := capturer.CaptureOutput(func() {
+		main()
+	})
+
+	assert.Equal(t, ExitTimeout, exitCode, "it should exit with timeout if the run did not finish in time")
+
+	for _, contain := range []string{
+		"- github.com/KEINOS/Hello-Cobra\n  Error: context deadline exceeded",
+		"- github.com/KEINOS/dev-go\n  Error: context deadline exceeded",
+		"Failed to score 2 of 2 packages:",
+	} {
+		assert.Contains(t, out, contain)
+	}
+}
+
+func TestRun_usage_error(t *testing.T) {
+	for _, args := range [][]string{
+		{"score"}, // missing package name
+		{"compare", "--unknown", "github.com/foo/bar"}, // unknown flag
+		{"score", "--format", "xml", "github.com/foo/bar"},
+		{"score", "--quiet", "--verbose", "github.com/foo/bar"},
+		{"--concurrency", "0", "github.com/foo/bar"},
+		{"score", "--level", "org", "github.com/foo/bar"},
+	} {
+		var exitCode int
+
+		out := capturer.CaptureOutput(func() {
+			exitCode = Run(args)
+		})
+
+		assert.Equal(t, ExitUsage, exitCode, "args: %v", args)
+		assert.Contains(t, out, "Usage:\n  gostars ", "it should print the usage on error. args: %v", args)
+	}
 }
 
 func TestExitOnError(t *testing.T) {
@@ -155,11 +371,112 @@ func TestExitOnError(t *testing.T) {
 	assert.Equal(t, expect, actual)
 }
 
+func Test_validateOptions(t *testing.T) {
+	require.NoError(t, validateOptions("markdown", "stars", "ASC"))
+
+	for _, test := range []struct {
+		format  string
+		sortBy  string
+		order   string
+		contain string
+	}{
+		{"xml", "", "desc", "unknown output format"},
+		{"table", "size", "desc", "unknown field to sort"},
+		{"table", "gravity", "random", "unknown sort order"},
+	} {
+		err := validateOptions(test.format, test.sortBy, test.order)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), test.contain)
+	}
+}
+
+func TestPrintLowGravity(t *testing.T) {
+	scores := []*gostars.Score{
+		{Name: "github.com/foo/low", Gravity: 5},
+		{Name: "github.com/foo/high", Gravity: 500},
+		{Name: "github.com/foo/failed", Err: errors.New("forced error")},
+	}
+
+	var out strings.Builder
+
+	PrintLowGravity(&out, scores, 100)
+
+	expect := "Low-gravity dependencies (gravity < 100): 1 of 3\n  - github.com/foo/low: 5\n"
+	assert.Equal(t, expect, out.String())
+
+	out.Reset()
+
+	PrintLowGravity(&out, scores, 1)
+	assert.Empty(t, out.String(), "it should print nothing if no low-gravity package")
+}
+
+func TestGetExitCode(t *testing.T) {
+	success := &gostars.Score{Name: "success"}
+	failure := &gostars.Score{Name: "failure", Err: errors.New("forced error")}
+
+	for _, test := range []struct {
+		scores []*gostars.Score
+		expect int
+	}{
+		{[]*gostars.Score{success, success}, ExitOK},
+		{[]*gostars.Score{success, failure}, ExitPartialFailure},
+		{[]*gostars.Score{failure, failure}, ExitFailure},
+	} {
+		assert.Equal(t, test.expect, GetExitCode(test.scores))
+	}
+}
+
+func TestPrintSummary(t *testing.T) {
+	scores := []*gostars.Score{
+		{Name: "github.com/foo/bar"},
+		{Name: "github.com/foo/undefined", Err: errors.New("forced error")},
+		{Name: "github.com/foo/baz"},
+	}
+
+	var out strings.Builder
+
+	PrintSummary(&out, scores)
+
+	expect := "Failed to score 1 of 3 packages:\n  - github.com/foo/undefined: forced error\n"
+	assert.Equal(t, expect, out.String())
+
+	// No failure
+	out.Reset()
+
+	PrintSummary(&out, scores[:1])
+	assert.Empty(t, out.String(), "it should print nothing if no package failed")
+}
+
+func TestGetInfoContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out, err := GetInfoContext(ctx, newFakeClient(t), "github.com/KEINOS/Hello-Cobra")
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, out, "it should be empty on error")
+}
+
+func TestGetInfo(t *testing.T) {
+	out, err := GetInfo(newFakeClient(t), "github.com/KEINOS/Hello-Cobra")
+	require.NoError(t, err)
+
+	assert.Equal(t, `- Hello-Cobra
+  1. Gravity:      11
+  2. Package Name: github.com/KEINOS/Hello-Cobra
+  3. URL:          https://github.com/KEINOS/Hello-Cobra
+  4. Stars:        11
+  5. Forks:        2
+  6. Folllows:     2
+  7. ImportedBy:   1`, out)
+}
+
 func TestGetInfo_bad_package_name(t *testing.T) {
 	namePkg := "github.com/KEINOS/undefined"
 	expectErr := "failed to get 'imported by' information"
 
-	out, err := GetInfo(namePkg)
+	out, err := GetInfo(newFakeClient(t), namePkg)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), expectErr)
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Options
+// ----------------------------------------------------------------------------
+
+// Options holds the command line options of the subcommands that score the
+// packages.
+type Options struct {
+	Timeout     time.Duration // Time limit of the whole run. 0 for no limit
+	Concurrency int           // Number of packages to score at once
+	Format      string        // Output format
+	Table       bool          // Same as Format = "table"
+	Sort        string        // Field to sort by. Empty to keep the given order
+	Order       string        // Sort order. "asc" or "desc"
+	Token       string        // GitHub personal access token
+	Quiet       bool          // Suppress the messages to STDERR
+	Verbose     bool          // Print the progress to STDERR
+	NoCache     bool          // Do not use the on-disk cache
+	Refresh     bool          // Revalidate the cached entries regardless of CacheTTL
+	CacheTTL    time.Duration // Time to live of the cached entries
+	NoHistory   bool          // Do not record the scores to the history
+	Model       string        // Path of the gravity model file in YAML or JSON
+	Weights     string        // Weights of the gravity dimensions. Such as "stars=1,imported_by=0.5"
+	Normalizer  string        // Normalization of the gravity dimensions
+	Explain     bool          // Print the breakdown of the gravity
+	Activity    bool          // Fetch the activity of the repositories
+	ReportCard  bool          // Fetch the Go Report Card of the repositories
+	Coverage    bool          // Fetch the code coverage of the repositories
+	Health      bool          // Fetch the health of the issues and pull requests
+	Aliases     string        // Path of the URL aliases file in YAML or JSON
+	Level       string        // Level to count the importers. "package", "module" or "repo"
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// NewFlagSet returns a flag set of the subcommand that parses the options into
+// opts. The default output format is given by format.
+func NewFlagSet(cmd *Command, opts *Options, format string) *flag.FlagSet {
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+
+	flags.DurationVar(&opts.Timeout, "timeout", 0, "time limit of the whole run. Such as 30s, 5m. 0 for no limit")
+	flags.IntVar(&opts.Concurrency, "concurrency", 4, "number of packages to score at once")
+	flags.StringVar(&opts.Format, "format", format, "output format. "+strings.Join(ListFormats(), ", "))
+	flags.BoolVar(&opts.Table, "table", false, "compare the packages in a table. Same as --format table")
+	flags.StringVar(&opts.Sort, "sort", "", "field to sort by. "+strings.Join(ListSortFields(), ", ")+
+		" (default: gravity for table formats, otherwise as given)")
+	flags.StringVar(&opts.Order, "order", "desc", "sort order. asc or desc")
+	flags.StringVar(&opts.Token, "token", "", "GitHub personal access token (default: $GITHUB_TOKEN, $GH_TOKEN, gh CLI config or ~/.netrc)")
+	flags.BoolVar(&opts.Quiet, "quiet", false, "suppress the messages to STDERR except fatal errors")
+	flags.BoolVar(&opts.Verbose, "verbose", false, "print the progress to STDERR")
+	flags.BoolVar(&opts.NoCache, "no-cache", false, "do not read nor write the on-disk cache")
+	flags.BoolVar(&opts.Refresh, "refresh", false, "fetch again and update the cache regardless of --cache-ttl")
+	flags.DurationVar(&opts.CacheTTL, "cache-ttl", gostars.CacheTTLDefault, "time to live of the cached data. Such as 1h, 30m")
+
+	flags.StringVar(&opts.Model, "model", "", "path of the gravity model file in YAML or JSON (default: gravity.yaml in the config dir if exists)")
+	flags.StringVar(&opts.Weights, "weights", "", "weights of the gravity dimensions. Such as stars=1,imported_by=0.5. Dimensions: "+
+		strings.Join(gostars.ListDimensions(), ", "))
+	flags.StringVar(&opts.Normalizer, "normalization", "", "normalization of the gravity dimensions. "+
+		strings.Join(gostars.ListNormalizations(), ", ")+" (default: none)")
+	flags.BoolVar(&opts.Explain, "explain", false, "print the breakdown of the gravity per dimension. Ignored for csv and tsv")
+	flags.BoolVar(&opts.Activity, "activity", false, "also fetch the commits, code frequency and releases of the repositories. "+
+		"Costs 3 more requests per package (default: only if the gravity model needs them)")
+	flags.BoolVar(&opts.ReportCard, "report-card", false, "also fetch the Go Report Card of the repositories "+
+		"(default: only if the gravity model needs it)")
+	flags.BoolVar(&opts.Coverage, "coverage", false, "also fetch the code coverage of the repositories "+
+		"(default: only if the gravity model needs it)")
+	flags.BoolVar(&opts.Health, "health", false, "also fetch the issue counts and the response times of the "+
+		"issues and pull requests (costs up to 7 more requests per package)")
+	flags.StringVar(&opts.Aliases, "aliases", "", "path of the URL aliases file in YAML or JSON "+
+		"(default: aliases.yaml or aliases.json in the config dir if exists)")
+	flags.StringVar(&opts.Level, "level", gostars.LevelPackage, "level to count the importers for the gravity. "+
+		strings.Join(gostars.ListLevels(), ", ")+". Such as the module of a package in a multi-module repository")
+	flags.BoolVar(&opts.NoHistory, "no-history", false, "do not record the scores to the history. See \"gostars history\"")
+
+	setUsage(flags, cmd)
+
+	return flags
+}
+
+// setUsage sets the usage message of the subcommand to flags.
+func setUsage(flags *flag.FlagSet, cmd *Command) {
+	flags.Usage = func() {
+		out := flags.Output()
+
+		fmt.Fprintf(out, "%s\n\nUsage:\n  gostars %s [options] %s\n\nOptions:\n", cmd.Summary, cmd.Name, cmd.Args)
+		flags.PrintDefaults()
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods
+// ----------------------------------------------------------------------------
+
+// Normalize applies the defaults that depend on other options and validates
+// the options.
+func (o *Options) Normalize() error {
+	if o.Table {
+		o.Format = "table"
+	}
+
+	o.Format = strings.ToLower(o.Format)
+	o.Order = strings.ToLower(o.Order)
+
+	if o.Sort == "" && IsTableFormat(o.Format) {
+		o.Sort = "gravity"
+	}
+
+	if o.Quiet && o.Verbose {
+		return errors.New("--quiet and --verbose can not be used together")
+	}
+
+	if o.Concurrency < 1 {
+		return errors.Errorf("--concurrency must be 1 or greater: %d", o.Concurrency)
+	}
+
+	if o.CacheTTL < 0 {
+		return errors.Errorf("--cache-ttl must be 0 or greater: %v", o.CacheTTL)
+	}
+
+	o.Level = strings.ToLower(o.Level)
+	if o.Level == "" {
+		o.Level = gostars.LevelPackage
+	}
+
+	switch o.Level {
+	case gostars.LevelPackage, gostars.LevelModule, gostars.LevelRepo:
+	default:
+		return errors.Errorf("unknown level: %q (available: %s)", o.Level, strings.Join(gostars.ListLevels(), ", "))
+	}
+
+	return validateOptions(o.Format, o.Sort, o.Order)
+}
+
+// SetupToken resolves the GitHub token and assigns it to the client. See
+// gostars.ResolveGitHubToken for the order of the sources. The --token option
+// has the highest priority.
+//
+// In verbose mode, it prints the source of the token but never the token.
+func (o *Options) SetupToken(client *gostars.Client) {
+	cred := gostars.ResolveGitHubToken(o.Token)
+
+	client.Token = cred.Token
+
+	switch cred.Source {
+	case gostars.TokenSourceNone:
+		o.Verbosef("no GitHub token is set. The requests to GitHub API are limited to 60 per hour")
+	case gostars.TokenSourceArgument:
+		o.Verbosef("using GitHub token from --token flag")
+	default:
+		o.Verbosef("using GitHub token from %s", cred)
+	}
+}
+
+// NewCache returns the on-disk cache as the options. It returns nil if the
+// cache is disabled or the cache directory is unknown.
+func (o *Options) NewCache() *gostars.Cache {
+	if o.NoCache {
+		return nil
+	}
+
+	dir, err := gostars.DefaultCacheDir()
+	if err != nil {
+		o.Verbosef("cache is disabled: %v", err)
+
+		return nil
+	}
+
+	o.Verbosef("using cache in %s (TTL: %v, refresh: %v)", dir, o.CacheTTL, o.Refresh)
+
+	cache := gostars.NewCache(dir, o.CacheTTL)
+	cache.Refresh = o.Refresh
+
+	return cache
+}
+
+// NewGravityModel returns the gravity model as the options. The model file is
+// read first, then --weights and --normalization override it.
+//
+// If --model is not set, gravity.yaml in the config directory is used if it
+// exists. Such as ~/.config/gostars/gravity.yaml on Linux. Otherwise the
+// default model of the original formula is used.
+func (o *Options) NewGravityModel() (*gostars.GravityModel, error) {
+	model := gostars.DefaultGravityModel()
+	pathModel := o.Model
+
+	if pathModel == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			if _, err := os.Stat(filepath.Join(dir, "gostars", "gravity.yaml")); err == nil {
+				pathModel = filepath.Join(dir, "gostars", "gravity.yaml")
+			}
+		}
+	}
+
+	if pathModel != "" {
+		o.Verbosef("using gravity model in %s", pathModel)
+
+		loaded, err := gostars.LoadGravityModel(pathModel)
+		if err != nil {
+			return nil, err
+		}
+
+		model = loaded
+	}
+
+	if o.Weights != "" {
+		for _, pair := range strings.Split(o.Weights, ",") {
+			name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok {
+				return nil, errors.Errorf("weight must be <dimension>=<weight>: %q", pair)
+			}
+
+			weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return nil, errors.Errorf("weight of %s must be a number: %q", name, value)
+			}
+
+			model.Weights[strings.ToLower(strings.TrimSpace(name))] = weight
+		}
+	}
+
+	if o.Normalizer != "" {
+		model.Normalization = strings.ToLower(o.Normalizer)
+	}
+
+	if err := model.Validate(); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// NewAliasRegistry returns the URL aliases of the user merged with the built-in
+// ones. The aliases of the user have priority. See LoadAliases for the file.
+func (o *Options) NewAliasRegistry() (*gostars.AliasRegistry, error) {
+	registry, _, err := o.LoadAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.Merge(gostars.DefaultAliasRegistry()), nil
+}
+
+// LoadAliases returns the URL aliases of the user and the path of the file.
+//
+// If --aliases is not set, aliases.yaml or aliases.json in the config directory
+// is used if it exists. Such as ~/.config/gostars/aliases.yaml on Linux. If
+// none, it returns an empty registry and an empty path.
+func (o *Options) LoadAliases() (*gostars.AliasRegistry, string, error) {
+	pathAliases := o.Aliases
+
+	if pathAliases == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			for _, name := range []string{"aliases.yaml", "aliases.json"} {
+				if _, err := os.Stat(filepath.Join(dir, "gostars", name)); err == nil {
+					pathAliases = filepath.Join(dir, "gostars", name)
+
+					break
+				}
+			}
+		}
+	}
+
+	if pathAliases == "" {
+		return new(gostars.AliasRegistry), "", nil
+	}
+
+	o.Verbosef("using URL aliases in %s", pathAliases)
+
+	registry, err := gostars.LoadAliasRegistry(pathAliases)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return registry, pathAliases, nil
+}
+
+// RecordHistory appends the snapshots of the succeeded scores to the history
+// file. Failing to record is reported to STDERR but not an error of the run.
+func (o *Options) RecordHistory(scores []*gostars.Score) {
+	if o.NoHistory {
+		return
+	}
+
+	path, err := gostars.DefaultHistoryPath()
+	if err == nil {
+		snapshots := []gostars.Snapshot{}
+		now := time.Now()
+
+		for _, score := range scores {
+			if snapshot, ok := gostars.NewSnapshot(score, now); ok {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+
+		o.Verbosef("recording %d snapshot(s) to %s", len(snapshots), path)
+
+		err = gostars.NewHistory(path).Append(snapshots...)
+	}
+
+	if err != nil && !o.Quiet {
+		fmt.Fprintln(os.Stderr, "Warning: failed to record history:", err)
+	}
+}
+
+// Verbosef prints the message to STDERR if the verbose option is set.
+func (o *Options) Verbosef(format string, a ...interface{}) {
+	if o.Verbose {
+		fmt.Fprintf(os.Stderr, "[gostars] "+format+"\n", a...)
+	}
+}
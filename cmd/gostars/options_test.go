@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zenizh/go-capturer"
+)
+
+// ----------------------------------------------------------------------------
+//  Tests
+// ----------------------------------------------------------------------------
+
+func TestOptions_SetupToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "token-from-env")
+
+	// From the environment variable
+	opts := &Options{Verbose: true}
+	client := gostars.NewClient()
+
+	out := capturer.CaptureStderr(func() {
+		opts.SetupToken(client)
+	})
+
+	assert.Equal(t, "token-from-env", client.Token)
+	assert.Contains(t, out, "using GitHub token from GITHUB_TOKEN environment variable")
+	assert.NotContains(t, out, "token-from-env", "it should never print the token")
+
+	// The flag has priority
+	opts.Token = "token-from-flag"
+	opts.Verbose = false
+
+	out = capturer.CaptureStderr(func() {
+		opts.SetupToken(client)
+	})
+
+	assert.Equal(t, "token-from-flag", client.Token)
+	assert.Empty(t, out, "it should print nothing if not verbose")
+}
+
+func TestOptions_NewCache(t *testing.T) {
+	dirCache := t.TempDir()
+
+	t.Setenv(gostars.EnvCacheDir, dirCache)
+
+	opts := &Options{CacheTTL: time.Hour, Refresh: true}
+
+	cache := opts.NewCache()
+	require.NotNil(t, cache)
+	assert.Equal(t, dirCache, cache.Dir)
+	assert.Equal(t, time.Hour, cache.TTL)
+	assert.True(t, cache.Refresh)
+
+	opts.NoCache = true
+
+	assert.Nil(t, opts.NewCache(), "--no-cache should disable the cache")
+
+	// Negative TTL
+	opts = &Options{Format: "text", Order: "desc", Concurrency: 1, CacheTTL: -time.Second}
+
+	require.Error(t, opts.Normalize())
+}
+
+func TestOptions_Normalize_level(t *testing.T) {
+	opts := &Options{Format: "text", Order: "desc", Concurrency: 1}
+
+	require.NoError(t, opts.Normalize())
+	assert.Equal(t, gostars.LevelPackage, opts.Level, "it should default to the package level")
+
+	opts.Level = "Module"
+
+	require.NoError(t, opts.Normalize())
+	assert.Equal(t, gostars.LevelModule, opts.Level)
+
+	opts.Level = "org"
+
+	err := opts.Normalize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown level: "org" (available: package, module, repo)`)
+}
+
+func TestOptions_NewGravityModel(t *testing.T) {
+	pathModel := filepath.Join(t.TempDir(), "gravity.yaml")
+
+	require.NoError(t, os.WriteFile(pathModel, []byte("normalization: log\nweights:\n  forks: 3\n"), 0o600))
+
+	opts := &Options{
+		Model:      pathModel,
+		Weights:    "stars=2, Imported_By=0.5",
+		Normalizer: "MinMax",
+	}
+
+	model, err := opts.NewGravityModel()
+	require.NoError(t, err)
+
+	assert.Equal(t, gostars.NormMinMax, model.Normalization, "flag should override the file")
+	assert.Equal(t, 2.0, model.Weights[gostars.DimStars])
+	assert.Equal(t, 3.0, model.Weights[gostars.DimForks])
+	assert.Equal(t, 1.0, model.Weights[gostars.DimFollowers])
+	assert.Equal(t, 0.5, model.Weights[gostars.DimImportedBy])
+
+	for _, opts := range []*Options{
+		{Weights: "stars"},
+		{Weights: "stars=many"},
+		{Weights: "unknown=1"},
+		{Normalizer: "cubic"},
+		{Model: pathModel + ".unknown"},
+	} {
+		_, err := opts.NewGravityModel()
+
+		require.Error(t, err, "options: %#v", opts)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Sorting
+// ----------------------------------------------------------------------------
+
+// sortKeys is the list of functions that return the value to sort per field
+// name of Record.
+var sortKeys = map[string]func(r Record) int{
+	"gravity":     func(r Record) int { return r.Gravity },
+	"stars":       func(r Record) int { return r.Stars },
+	"forks":       func(r Record) int { return r.Forks },
+	"followers":   func(r Record) int { return r.Followers },
+	"imported_by": func(r Record) int { return r.ImportedBy },
+}
+
+// markdownEscaper escapes the cells of the Markdown table. The pipes would split
+// the cell and the line breaks would end the row. Such as in the error messages.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+
+// ListSortFields returns the sorted names of the fields available to sort.
+func ListSortFields() []string {
+	names := []string{"package"}
+
+	for name := range sortKeys {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// SortScores sorts the scores by the field of Record. The packages failed to
+// score are always placed at the end in the original order.
+func SortScores(scores []*gostars.Score, field string, ascending bool) error {
+	field = strings.ToLower(field)
+
+	less := func(a, b Record) bool {
+		return a.Package < b.Package
+	}
+
+	if field != "package" {
+		key, ok := sortKeys[field]
+		if !ok {
+			return errors.Errorf("unknown field to sort: %q (available: %s)",
+				field, strings.Join(ListSortFields(), ", "))
+		}
+
+		less = func(a, b Record) bool {
+			return key(a) < key(b)
+		}
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Err != nil || scores[j].Err != nil {
+			return scores[i].Err == nil && scores[j].Err != nil
+		}
+
+		a, b := NewRecord(scores[i]), NewRecord(scores[j])
+		if ascending {
+			return less(a, b)
+		}
+
+		return less(b, a)
+	})
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Table
+// ----------------------------------------------------------------------------
+
+// tableHeader is the header row of the comparison table.
+var tableHeader = []string{
+	"Rank", "Package", "Gravity", "% of Leader", "Stars", "Forks", "Followers", "Imported By",
+}
+
+// NewTable returns the rows of the comparison table of the scores in the given
+// order. The first row is the header.
+//
+// "Rank" is the ranking by gravity and "% of Leader" is the ratio of gravity to
+// the package with the highest gravity. The "Error" column is added only if any
+// of the packages failed to score.
+func NewTable(scores []*gostars.Score) [][]string {
+	header := append([]string{}, tableHeader...)
+	hasError := countFailed(scores) > 0
+
+	if hasError {
+		header = append(header, "Error")
+	}
+
+	ranks, leader := rankByGravity(scores)
+	table := [][]string{header}
+
+	for index, score := range scores {
+		record := NewRecord(score)
+
+		if score.Err != nil {
+			row := []string{"-", record.Package, "-", "-", "-", "-", "-", "-", record.Error}
+			table = append(table, row)
+
+			continue
+		}
+
+		percent := "-"
+		if leader > 0 {
+			percent = fmt.Sprintf("%.1f%%", float64(record.Gravity)*100/float64(leader))
+		}
+
+		row := []string{
+			strconv.Itoa(ranks[index]),
+			record.Package,
+			strconv.Itoa(record.Gravity),
+			percent,
+			strconv.Itoa(record.Stars),
+			strconv.Itoa(record.Forks),
+			strconv.Itoa(record.Followers),
+			strconv.Itoa(record.ImportedBy),
+		}
+
+		if hasError {
+			row = append(row, "")
+		}
+
+		table = append(table, row)
+	}
+
+	return table
+}
+
+// WriteTable writes the scores as a comparison table with aligned columns.
+func WriteTable(w io.Writer, scores []*gostars.Score) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	for _, row := range NewTable(scores) {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return errors.Wrap(writer.Flush(), "failed to write output")
+}
+
+// WriteMarkdown writes the scores as a comparison table in Markdown.
+func WriteMarkdown(w io.Writer, scores []*gostars.Score) error {
+	table := NewTable(scores)
+	lines := make([]string, 0, len(table)+1)
+
+	for index, row := range table {
+		cells := make([]string, len(row))
+
+		for i, cell := range row {
+			cells[i] = markdownEscaper.Replace(cell)
+		}
+
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+
+		if index == 0 {
+			lines = append(lines, markdownAlignRow(row))
+		}
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+
+	return errors.Wrap(err, "failed to write output")
+}
+
+// markdownAlignRow returns the delimiter row of Markdown table for the header.
+// The numeric columns are aligned to the right.
+func markdownAlignRow(header []string) string {
+	aligns := make([]string, len(header))
+
+	for i, name := range header {
+		switch name {
+		case "Package", "Error":
+			aligns[i] = ":---"
+		default:
+			aligns[i] = "---:"
+		}
+	}
+
+	return "| " + strings.Join(aligns, " | ") + " |"
+}
+
+// rankByGravity returns the ranking by gravity per index of scores and the
+// highest gravity. The same gravity has the same rank. The packages failed to
+// score have rank 0.
+func rankByGravity(scores []*gostars.Score) ([]int, int) {
+	gravities := []int{}
+
+	for _, score := range scores {
+		if score.Err == nil {
+			gravities = append(gravities, score.Gravity)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(gravities)))
+
+	ranks := make([]int, len(scores))
+
+	for index, score := range scores {
+		if score.Err != nil {
+			continue
+		}
+
+		// Number of packages with higher gravity + 1
+		ranks[index] = sort.Search(len(gravities), func(i int) bool {
+			return gravities[i] <= score.Gravity
+		}) + 1
+	}
+
+	leader := 0
+	if len(gravities) > 0 {
+		leader = gravities[0]
+	}
+
+	return ranks, leader
+}
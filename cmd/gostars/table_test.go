@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Examples (Tests for golden-cases)
+// ----------------------------------------------------------------------------
+
+func ExampleWriteMarkdown() {
+	scores := append(sampleScores(), &gostars.Score{
+		Name:    "github.com/KEINOS/go-utiles",
+		Pkg:     &gostars.PkgInfo{ImportedBy: 40},
+		Repo:    &gostars.RepoInfo{Stars: 1, Forks: 2, Followers: 3},
+		Gravity: 40,
+	})
+
+	if err := SortScores(scores, "gravity", false); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := WriteMarkdown(os.Stdout, scores); err != nil {
+		log.Fatal(err)
+	}
+
+	// Output:
+	// | Rank | Package | Gravity | % of Leader | Stars | Forks | Followers | Imported By | Error |
+	// | ---: | :--- | ---: | ---: | ---: | ---: | ---: | ---: | :--- |
+	// | 1 | github.com/KEINOS/go-utiles | 40 | 100.0% | 1 | 2 | 3 | 40 |  |
+	// | 2 | github.com/KEINOS/dev-go | 10 | 25.0% | 10 | 3 | 2 | 2 |  |
+	// | - | github.com/KEINOS/undefined | - | - | - | - | - | - | not found |
+}
+
+func ExampleWriteTable() {
+	scores := append(sampleScores()[:1], &gostars.Score{
+		Name:    "github.com/KEINOS/go-utiles",
+		Pkg:     &gostars.PkgInfo{ImportedBy: 40},
+		Repo:    &gostars.RepoInfo{Stars: 1, Forks: 2, Followers: 3},
+		Gravity: 40,
+	})
+
+	// Sort by stars in descending order
+	if err := SortScores(scores, "stars", false); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := WriteTable(os.Stdout, scores); err != nil {
+		log.Fatal(err)
+	}
+
+	// Output:
+	// Rank  Package                      Gravity  % of Leader  Stars  Forks  Followers  Imported By
+	// 2     github.com/KEINOS/dev-go     10       25.0%        10     3      2          2
+	// 1     github.com/KEINOS/go-utiles  40       100.0%       1      2      3          40
+}
+
+// ----------------------------------------------------------------------------
+//  Tests
+// ----------------------------------------------------------------------------
+
+func TestSortScores(t *testing.T) {
+	newScore := func(name string, gravity int) *gostars.Score {
+		return &gostars.Score{
+			Name:    name,
+			Pkg:     &gostars.PkgInfo{},
+			Repo:    &gostars.RepoInfo{},
+			Gravity: gravity,
+		}
+	}
+
+	failed := &gostars.Score{Name: "failed", Err: errors.New("forced error")}
+	scores := []*gostars.Score{failed, newScore("b", 20), newScore("a", 20), newScore("c", 30)}
+
+	getNames := func() string {
+		names := []string{}
+		for _, score := range scores {
+			names = append(names, score.Name)
+		}
+
+		return strings.Join(names, ",")
+	}
+
+	require.NoError(t, SortScores(scores, "gravity", false))
+	assert.Equal(t, "c,b,a,failed", getNames(), "failed package should be at the end")
+
+	require.NoError(t, SortScores(scores, "Gravity", true))
+	assert.Equal(t, "b,a,c,failed", getNames(), "the sort should be stable")
+
+	require.NoError(t, SortScores(scores, "package", true))
+	assert.Equal(t, "a,b,c,failed", getNames())
+
+	err := SortScores(scores, "unknown", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field to sort: "unknown"`)
+}
+
+func TestWriteMarkdown_escape(t *testing.T) {
+	scores := []*gostars.Score{
+		{Name: "github.com/foo/bar", Err: errors.New("line 1 | a\nline 2\r\nline 3\rline 4")},
+	}
+
+	var out strings.Builder
+
+	require.NoError(t, WriteMarkdown(&out, scores))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+
+	require.Len(t, lines, 3, "the error should not break the row")
+	assert.Equal(t, `| - | github.com/foo/bar | - | - | - | - | - | - | line 1 \| a line 2 line 3 line 4 |`, lines[2])
+}
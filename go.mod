@@ -10,7 +10,9 @@ require (
 	github.com/russross/blackfriday v1.6.0
 	github.com/stretchr/testify v1.7.5
 	github.com/zenizh/go-capturer v0.0.0-20211219060012-52ea6c8fed04
+	golang.org/x/mod v0.30.0
 	golang.org/x/oauth2 v0.27.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -33,5 +35,4 @@ require (
 	golang.org/x/text v0.31.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/protobuf v1.33.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,223 @@
+package gostars
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: AwesomeEntry
+// ============================================================================
+
+// AwesomeEntry is a package listed in the awesome-go list.
+type AwesomeEntry struct {
+	Name        string `json:"name"`        // Name of the package. Such as "gostars"
+	URL         string `json:"url"`         // URL of the package. Site URLs are resolved via AliasRegistry
+	Description string `json:"description"` // Description of the package
+	Category    string `json:"category"`    // Name of the heading the package is listed under
+}
+
+// PackageName returns the package name of the entry from its URL. Such as
+// "github.com/owner/repo" from "https://github.com/owner/repo/", and
+// "github.com/owner/repo/sub" from "https://github.com/owner/repo/tree/master/sub".
+//
+// The URL is parsed with NewURLInfo. Thus "www." of the host, ".git" of the
+// repository and the path to browse the files are removed.
+func (e AwesomeEntry) PackageName() string {
+	urlInfo, err := NewURLInfo(e.URL)
+	if err != nil || urlInfo.Host == "" {
+		return strings.TrimSuffix(e.URL, "/")
+	}
+
+	return strings.TrimSuffix(urlInfo.Host+"/"+strings.Join(urlInfo.Path, "/"), "/")
+}
+
+// ============================================================================
+//  Type: AwesomeGo
+// ============================================================================
+
+// AwesomeGo is the catalogue of the packages listed in the README.md of
+// awesome-go. It holds the entries per category in the order of the list.
+//
+// The categories are the headings in the README. The entries of a category
+// include the entries of its subcategories. Such as "Database" includes the
+// ones under "Caches".
+type AwesomeGo struct {
+	categories []string                  // Category names in the order of appearance
+	parents    map[string]string         // Lower-cased category name to its parent category
+	entries    map[string][]AwesomeEntry // Lower-cased category name to its own entries
+}
+
+// ============================================================================
+//  Constructor
+// ============================================================================
+
+// NewAwesomeGo downloads the README.md from URLAwesomeGo and returns the
+// catalogue. It is a wrapper of DefaultClient.NewAwesomeGo.
+func NewAwesomeGo(ctx context.Context) (*AwesomeGo, error) {
+	return DefaultClient.NewAwesomeGo(ctx)
+}
+
+// NewAwesomeGo downloads the README.md from URLAwesomeGo and returns the
+// catalogue.
+func (c *Client) NewAwesomeGo(ctx context.Context) (*AwesomeGo, error) {
+	markdown, err := c.GetContentURLContext(ctx, URLAwesomeGo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download awesome-go list")
+	}
+
+	return parseAwesomeGo(markdown, c.getAliases())
+}
+
+// ParseAwesomeGo parses the markdown of awesome-go's README.md and returns the
+// catalogue.
+//
+// The links in the list items under "##" and "###" headings are the entries.
+// The links to the anchors in the page, such as the table of contents, are
+// ignored. The URLs are resolved with DefaultAliasRegistry.
+func ParseAwesomeGo(markdown []byte) (*AwesomeGo, error) {
+	return parseAwesomeGo(markdown, DefaultAliasRegistry())
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// Categories returns the names of the categories that have at least one entry
+// in the order of the list.
+func (a *AwesomeGo) Categories() []string {
+	result := []string{}
+
+	for _, category := range a.categories {
+		if len(a.Entries(category)) > 0 {
+			result = append(result, category)
+		}
+	}
+
+	return result
+}
+
+// Entries returns the entries of the category including its subcategories.
+// The category name is case-insensitive. It returns nil if the category does
+// not exist.
+func (a *AwesomeGo) Entries(category string) []AwesomeEntry {
+	key := strings.ToLower(strings.TrimSpace(category))
+
+	if _, ok := a.parents[key]; !ok {
+		return nil
+	}
+
+	result := append([]AwesomeEntry{}, a.entries[key]...)
+
+	// Entries of the subcategories
+	for _, sub := range a.categories {
+		keySub := strings.ToLower(sub)
+
+		if a.parents[keySub] == key && keySub != key {
+			result = append(result, a.entries[keySub]...)
+		}
+	}
+
+	return result
+}
+
+func (a *AwesomeGo) addCategory(category, parent string) {
+	key := strings.ToLower(category)
+
+	if _, ok := a.parents[key]; ok {
+		return
+	}
+
+	a.categories = append(a.categories, category)
+	a.parents[key] = strings.ToLower(parent)
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// parseAwesomeGo is the same as ParseAwesomeGo but resolves the URLs of the
+// entries with the aliases. Such as the ones of the Client.
+func parseAwesomeGo(markdown []byte, aliases *AliasRegistry) (*AwesomeGo, error) {
+	query, err := NewQuery([]byte(ParseMarkdownToHTML(markdown)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse awesome-go list")
+	}
+
+	awesome := &AwesomeGo{
+		parents: map[string]string{},
+		entries: map[string][]AwesomeEntry{},
+	}
+
+	var category, parent string
+
+	query.Find("body").Children().Each(func(_ int, s *goquery.Selection) {
+		switch goquery.NodeName(s) {
+		case "h2":
+			category = strings.TrimSpace(s.Text())
+			parent = category
+
+			awesome.addCategory(category, "")
+		case "h3":
+			category = strings.TrimSpace(s.Text())
+
+			awesome.addCategory(category, parent)
+		case "ul":
+			if category == "" {
+				return
+			}
+
+			s.Find("li").Each(func(_ int, item *goquery.Selection) {
+				if entry, ok := parseAwesomeItem(item, aliases); ok {
+					entry.Category = category
+
+					key := strings.ToLower(category)
+					awesome.entries[key] = append(awesome.entries[key], entry)
+				}
+			})
+		}
+	})
+
+	if len(awesome.categories) == 0 {
+		return nil, errors.New("no category found in awesome-go list")
+	}
+
+	return awesome, nil
+}
+
+// parseAwesomeItem parses a list item such as "[name](url) - description." to
+// AwesomeEntry. It returns false if the item is not a link to an external site.
+// The URL is resolved with the aliases.
+func parseAwesomeItem(item *goquery.Selection, aliases *AliasRegistry) (AwesomeEntry, bool) {
+	link := item.ChildrenFiltered("a").First()
+	if link.Length() == 0 {
+		// Loose list items are wrapped with <p>
+		link = item.ChildrenFiltered("p").ChildrenFiltered("a").First()
+	}
+
+	href, ok := link.Attr("href")
+	if !ok || !(strings.HasPrefix(href, "https://") || strings.HasPrefix(href, "http://")) {
+		return AwesomeEntry{}, false
+	}
+
+	name := strings.TrimSpace(link.Text())
+
+	// Text of the item without the nested lists
+	text := item.Clone()
+	text.Find("ul").Remove()
+
+	description := strings.TrimSpace(text.Text())
+	description = strings.TrimSpace(strings.TrimPrefix(description, name))
+	description = strings.TrimSpace(strings.TrimLeft(description, "-–—:"))
+
+	urlResolved, _, _ := aliases.Resolve(href)
+
+	return AwesomeEntry{
+		Name:        name,
+		URL:         urlResolved,
+		Description: description,
+	}, true
+}
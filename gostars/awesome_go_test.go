@@ -0,0 +1,135 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  AwesomeGo
+// ----------------------------------------------------------------------------
+
+func TestClient_NewAwesomeGo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `
+## JSON
+
+* [gjson](https://github.com/tidwall/gjson) — Get a JSON value with one line of code.
+* [go-json](https://github.com/goccy/go-json)
+* Not a link
+`)
+	}))
+	defer server.Close()
+
+	oldURLAwesomeGo := gostars.URLAwesomeGo
+	defer func() {
+		gostars.URLAwesomeGo = oldURLAwesomeGo
+	}()
+
+	gostars.URLAwesomeGo = server.URL + "/README.md"
+
+	client := newFakeClient(server)
+
+	awesome, err := client.NewAwesomeGo(context.Background())
+	require.NoError(t, err)
+
+	entries := awesome.Entries("json")
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, gostars.AwesomeEntry{
+		Name:        "gjson",
+		URL:         "https://github.com/tidwall/gjson",
+		Description: "Get a JSON value with one line of code.",
+		Category:    "JSON",
+	}, entries[0])
+	assert.Empty(t, entries[1].Description)
+
+	assert.Nil(t, awesome.Entries("unknown"), "unknown category should be nil")
+}
+
+func TestClient_NewAwesomeGo_aliases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `
+## Utilities
+
+* [dev-go](https://dev-go.example.com/tools) - Tools of dev-go.
+* [gjson](https://github.com/tidwall/gjson) - Get a JSON value with one line of code.
+`)
+	}))
+	defer server.Close()
+
+	oldURLAwesomeGo := gostars.URLAwesomeGo
+	defer func() {
+		gostars.URLAwesomeGo = oldURLAwesomeGo
+	}()
+
+	gostars.URLAwesomeGo = server.URL + "/README.md"
+
+	registry, err := gostars.NewAliasRegistry(gostars.URLAlias{
+		From: "https://dev-go.example.com/", To: "https://github.com/KEINOS/dev-go/", Match: gostars.MatchPrefix,
+	})
+	require.NoError(t, err)
+
+	client := newFakeClient(server)
+	client.Aliases = registry
+
+	awesome, err := client.NewAwesomeGo(context.Background())
+	require.NoError(t, err)
+
+	entries := awesome.Entries("utilities")
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "https://github.com/KEINOS/dev-go/tools", entries[0].URL, "the aliases of the client should be used")
+	assert.Equal(t, "https://github.com/tidwall/gjson", entries[1].URL)
+}
+
+func TestAwesomeEntry_PackageName(t *testing.T) {
+	for _, test := range []struct {
+		url    string
+		expect string
+	}{
+		{"https://github.com/tidwall/gjson", "github.com/tidwall/gjson"},
+		{"https://github.com/tidwall/gjson/", "github.com/tidwall/gjson"},
+		{"http://www.gorillatoolkit.org/pkg/mux", "gorillatoolkit.org/pkg/mux"},
+		{"https://github.com/tidwall/gjson.git", "github.com/tidwall/gjson"},
+		{"https://github.com/aws/aws-sdk-go-v2/tree/main/service/s3", "github.com/aws/aws-sdk-go-v2/service/s3"},
+		{"https://gitlab.com/group/sub/repo/-/tree/master/cmd", "gitlab.com/group/sub/repo/cmd"},
+		{"https://bitbucket.org/owner/repo/src/master/", "bitbucket.org/owner/repo"},
+		{"https://GitHub.com/tidwall/gjson", "github.com/tidwall/gjson"},
+	} {
+		entry := gostars.AwesomeEntry{URL: test.url}
+
+		assert.Equal(t, test.expect, entry.PackageName(), "url: %s", test.url)
+	}
+}
+
+func TestClient_NewAwesomeGo_fail(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	oldURLAwesomeGo := gostars.URLAwesomeGo
+	defer func() {
+		gostars.URLAwesomeGo = oldURLAwesomeGo
+	}()
+
+	// Not found
+	gostars.URLAwesomeGo = server.URL + "/unknown/README.md"
+
+	_, err := client.NewAwesomeGo(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to download awesome-go list")
+
+	// No category
+	gostars.URLAwesomeGo = server.URL + "/content/hello.txt"
+
+	_, err = client.NewAwesomeGo(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no category found")
+}
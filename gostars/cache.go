@@ -0,0 +1,187 @@
+package gostars
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EnvCacheDir is the name of the environment variable to change the directory
+// of the on-disk cache. See DefaultCacheDir.
+const EnvCacheDir = "GOSTARS_CACHE_DIR"
+
+// CacheTTLDefault is the default time to live of the cached entries.
+const CacheTTLDefault = 24 * time.Hour
+
+// ============================================================================
+//  Type: Cache
+// ============================================================================
+
+// Cache is an on-disk cache of the responses from the remote services. Such as
+// PkgInfo, RepoInfo and the contents of GetContentURL.
+//
+// Each entry is stored as a JSON file named after the Hash256 of its key. The
+// entries older than TTL are revalidated. For the servers that support ETag,
+// such as GitHub API, the request is conditional (If-None-Match) and a "304 Not
+// Modified" response renews the entry. Which does not consume the rate limit of
+// GitHub API.
+//
+// The cache is best effort. Failing to read or write an entry never fails the
+// request. A nil Cache is a valid cache that caches nothing.
+type Cache struct {
+	Dir     string        // Directory to store the entries
+	TTL     time.Duration // Time to live of the entries. 0 or less to always revalidate
+	Refresh bool          // If true, the entries are revalidated regardless of TTL
+}
+
+// cacheEntry is the content of a cache file.
+type cacheEntry struct {
+	Key     string          `json:"key"`            // Key of the entry. Such as "repo:https://api.github.com/repos/owner/name"
+	ETag    string          `json:"etag,omitempty"` // ETag of the response if any
+	Updated time.Time       `json:"updated"`        // Time when the entry was fetched or revalidated
+	Data    json.RawMessage `json:"data"`           // Cached value
+}
+
+// ============================================================================
+//  Constructor
+// ============================================================================
+
+// NewCache returns a new Cache that stores the entries under dir.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{
+		Dir: dir,
+		TTL: ttl,
+	}
+}
+
+// DefaultCacheDir returns the default directory of the cache. Which is the
+// value of GOSTARS_CACHE_DIR environment variable if set, otherwise "gostars"
+// under the user cache directory. Such as ~/.cache/gostars on Linux.
+func DefaultCacheDir() (string, error) {
+	if dir := os.Getenv(EnvCacheDir); dir != "" {
+		return dir, nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get user cache directory")
+	}
+
+	return filepath.Join(dir, "gostars"), nil
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// Clear removes all the cached entries.
+func (c *Cache) Clear() error {
+	if c == nil {
+		return nil
+	}
+
+	return errors.Wrap(os.RemoveAll(c.Dir), "failed to clear cache")
+}
+
+// isFresh returns true if the entry can be used without revalidation.
+func (c *Cache) isFresh(entry *cacheEntry) bool {
+	if c == nil || entry == nil || c.Refresh {
+		return false
+	}
+
+	return time.Since(entry.Updated) < c.TTL
+}
+
+// load returns the entry of the key regardless of its age. It returns nil if
+// not cached.
+func (c *Cache) load(key string) *cacheEntry {
+	if c == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil
+	}
+
+	entry := new(cacheEntry)
+
+	// Also ignore the collision of the hash
+	if err := json.Unmarshal(data, entry); err != nil || entry.Key != key {
+		return nil
+	}
+
+	return entry
+}
+
+// path returns the file path of the entry.
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, Hash256([]byte(key))+".json")
+}
+
+// store saves the value as the entry of the key. The file is replaced
+// atomically to be safe with the concurrent requests.
+func (c *Cache) store(key, etag string, value interface{}) {
+	if c == nil {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	entry, err := json.Marshal(cacheEntry{
+		Key:     key,
+		ETag:    etag,
+		Updated: time.Now(),
+		Data:    data,
+	})
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return
+	}
+
+	fileTemp, err := os.CreateTemp(c.Dir, "*.tmp")
+	if err != nil {
+		return
+	}
+
+	_, err = fileTemp.Write(entry)
+
+	if errClose := fileTemp.Close(); err == nil {
+		err = errClose
+	}
+
+	if err == nil {
+		err = os.Rename(fileTemp.Name(), c.path(key))
+	}
+
+	if err != nil {
+		_ = os.Remove(fileTemp.Name())
+	}
+}
+
+// decode decodes the cached value into v.
+func (e *cacheEntry) decode(v interface{}) error {
+	if e == nil {
+		return errors.New("no cache entry")
+	}
+
+	return errors.Wrap(json.Unmarshal(e.Data, v), "malformed cache entry")
+}
+
+// getETag returns the ETag of the entry. It returns an empty string if nil.
+func (e *cacheEntry) getETag() string {
+	if e == nil {
+		return ""
+	}
+
+	return e.ETag
+}
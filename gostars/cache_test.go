@@ -0,0 +1,118 @@
+package gostars_test
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Cache
+// ----------------------------------------------------------------------------
+
+func TestCache_fresh(t *testing.T) {
+	server := newFakeServer(t)
+	counts := countRequests(t, server)
+
+	client := newFakeClient(server)
+	client.Cache = gostars.NewCache(t.TempDir(), time.Hour)
+
+	for i := 0; i < 3; i++ {
+		content, err := client.GetContentURL(server.URL + "/content/hello.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, world!", string(content))
+
+		pkgInfo, err := client.NewPkgInfo("github.com/KEINOS/dev-go")
+		require.NoError(t, err)
+		assert.Equal(t, 2, pkgInfo.ImportedBy)
+		assert.Equal(t, "https://github.com/KEINOS/dev-go", pkgInfo.Repository)
+
+		repoInfo, err := client.NewRepoInfo("https://github.com/KEINOS/dev-go")
+		require.NoError(t, err)
+		assert.Equal(t, 10, repoInfo.Stars)
+		assert.Equal(t, "Dockerfile for Go development", repoInfo.Description)
+	}
+
+	assert.Equal(t, 1, counts["/content/hello.txt"], "fresh content should not be requested again")
+	// The repository under github.com is resolved without requesting pkg.go.dev
+	assert.Equal(t, 1, counts["/pkg/github.com/KEINOS/dev-go"], "fresh package info should not be requested again")
+	assert.Equal(t, 1, counts["/api/repos/KEINOS/dev-go"], "fresh repository info should not be requested again")
+}
+
+func TestCache_revalidate_etag(t *testing.T) {
+	var numNotModified int
+
+	server := newFakeServer(t)
+	handler := server.Config.Handler
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			numNotModified++
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+
+	client := newFakeClient(server)
+	client.Cache = gostars.NewCache(t.TempDir(), 0) // always revalidate
+
+	for i := 0; i < 3; i++ {
+		repoInfo, err := client.NewRepoInfo("https://github.com/KEINOS/dev-go")
+		require.NoError(t, err)
+		assert.Equal(t, 10, repoInfo.Stars, "the cached value should be used on 304")
+		assert.Equal(t, 3, repoInfo.Forks, "the cached value should be used on 304")
+	}
+
+	assert.Equal(t, 2, numNotModified, "stale entry should be revalidated with If-None-Match")
+}
+
+func TestCache_refresh(t *testing.T) {
+	server := newFakeServer(t)
+	counts := countRequests(t, server)
+	dirCache := t.TempDir()
+
+	client := newFakeClient(server)
+	client.Cache = gostars.NewCache(dirCache, time.Hour)
+
+	_, err := client.GetContentURL(server.URL + "/content/hello.txt")
+	require.NoError(t, err)
+
+	client.Cache.Refresh = true
+
+	_, err = client.GetContentURL(server.URL + "/content/hello.txt")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, counts["/content/hello.txt"], "it should ignore the fresh entry on refresh")
+
+	// Clear
+	require.NoError(t, client.Cache.Clear())
+	assert.NoDirExists(t, dirCache)
+
+	// Errors are not cached
+	_, err = client.GetContentURL(server.URL + "/unknown")
+	require.Error(t, err)
+
+	_, err = client.GetContentURL(server.URL + "/unknown")
+	require.Error(t, err)
+	assert.Equal(t, 2, counts["/unknown"])
+}
+
+func TestDefaultCacheDir(t *testing.T) {
+	t.Setenv(gostars.EnvCacheDir, "/path/to/cache")
+
+	dir, err := gostars.DefaultCacheDir()
+	require.NoError(t, err)
+	assert.Equal(t, "/path/to/cache", dir)
+
+	t.Setenv(gostars.EnvCacheDir, "")
+	t.Setenv("XDG_CACHE_HOME", "/path/to/xdg")
+
+	if dir, err = gostars.DefaultCacheDir(); err == nil {
+		assert.Equal(t, "gostars", filepath.Base(dir))
+	}
+}
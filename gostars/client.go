@@ -0,0 +1,440 @@
+package gostars
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	pkggodevclient "github.com/guseggert/pkggodev-client"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// ============================================================================
+//  Type: Client
+// ============================================================================
+
+// Client holds the settings to access the remote services such as GitHub API
+// and pkg.go.dev.
+//
+// All the fields can be changed to point to other servers. Such as a mock
+// server of httptest for testing.
+type Client struct {
+	HTTPClient        *http.Client            // HTTP client used for all the requests
+	GitHubBaseURL     string                  // Base URL of GitHub REST API
+	PkgGoDevBaseURL   string                  // Base URL of pkg.go.dev
+	ReportCardBaseURL string                  // Base URL of Go Report Card
+	CodecovBaseURL    string                  // Base URL of Codecov API
+	CoverallsBaseURL  string                  // Base URL of Coveralls
+	RawContentBaseURL string                  // Base URL of the raw contents of GitHub repositories
+	GoProxyBaseURL    string                  // Base URL of the Go module proxy
+	Providers         map[string]RepoProvider // Providers of the repositories per host. DefaultRepoProviders if nil
+	Aliases           *AliasRegistry          // Aliases of the repository URLs. DefaultAliasRegistry if nil
+	Token             string                  // GitHub personal access token. See ResolveGitHubToken to get it from the environment
+	RateLimiter       *RateLimiter            // Throttles the requests per host. No limit if nil
+	Concurrency       int                     // Number of packages to score at once in ScoreAll
+	Cache             *Cache                  // On-disk cache of the responses. No cache if nil
+	GravityModel      *GravityModel           // Formula of the gravity. DefaultGravityModel if nil
+	Activity          bool                    // Also fetch the activity of the repositories. See RepoInfo.UpdateActivity
+	ReportCard        bool                    // Also fetch the Go Report Card of the repositories. See NewReportCardInfo
+	Coverage          bool                    // Also fetch the code coverage of the repositories. See NewCoverageInfo
+	Health            bool                    // Also fetch the health of the repositories. See RepoInfo.UpdateHealth
+	HealthWindow      time.Duration           // Window of the issues and pull requests for the health. 180 days if 0
+	Level             string                  // Level to count the importers for the gravity. LevelPackage if empty
+}
+
+// ============================================================================
+//  Constructor
+// ============================================================================
+
+// NewClient returns a new Client with the default settings.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient:        http.DefaultClient,
+		GitHubBaseURL:     urlGitHubAPIDefault,
+		PkgGoDevBaseURL:   urlPkgGoDevDefault,
+		ReportCardBaseURL: urlReportCardDefault,
+		CodecovBaseURL:    urlCodecovDefault,
+		CoverallsBaseURL:  urlCoverallsDefault,
+		RawContentBaseURL: urlRawContentDefault,
+		GoProxyBaseURL:    urlGoProxyDefault,
+		Providers:         DefaultRepoProviders(),
+		Aliases:           DefaultAliasRegistry(),
+		RateLimiter:       NewRateLimiter(),
+		Concurrency:       concurrencyDefault,
+	}
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// GetContentURL returns the content of a given URL.
+//
+// To avoid a large number of requests to the target server, the request is
+// throttled by the RateLimiter of the client. If the client has a Cache, the
+// content is cached and revalidated with ETag if the server supports it.
+func (c *Client) GetContentURL(urlTarget string) ([]byte, error) {
+	return c.GetContentURLContext(context.Background(), urlTarget)
+}
+
+// GetContentURLContext is the same as GetContentURL but with a context to cancel
+// the request.
+func (c *Client) GetContentURLContext(ctx context.Context, urlTarget string) ([]byte, error) {
+	content, _, err := c.getContent(ctx, urlTarget)
+
+	return content, err
+}
+
+// NewPkgInfo returns the initialized object of PkgInfo from pkgName using the
+// client settings.
+func (c *Client) NewPkgInfo(pkgName string) (*PkgInfo, error) {
+	return c.NewPkgInfoContext(context.Background(), pkgName)
+}
+
+// NewPkgInfoContext is the same as NewPkgInfo but with a context to cancel the
+// requests.
+func (c *Client) NewPkgInfoContext(ctx context.Context, pkgName string) (*PkgInfo, error) {
+	pkgInfo := &PkgInfo{
+		Name:   pkgName,
+		client: c,
+	}
+
+	if err := pkgInfo.UpdateContext(ctx); err != nil {
+		return nil, err
+	}
+
+	return pkgInfo, nil
+}
+
+// NewRepoInfo returns the initialized object of RepoInfo from the given URL of
+// the repository using the client settings. The host must be one of Providers.
+func (c *Client) NewRepoInfo(urlRepo string) (*RepoInfo, error) {
+	return c.NewRepoInfoContext(context.Background(), urlRepo)
+}
+
+// NewRepoInfoContext is the same as NewRepoInfo but with a context to cancel the
+// requests.
+func (c *Client) NewRepoInfoContext(ctx context.Context, urlRepo string) (*RepoInfo, error) {
+	// Get the actual URL of the repository from the mapping. (Aliases)
+	urlRepo, _, _ = c.getAliases().Resolve(urlRepo)
+
+	urlInfo, err := NewURLInfo(urlRepo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get URL information")
+	}
+
+	repoInfo := &RepoInfo{
+		URL:    urlInfo,
+		client: c,
+	}
+
+	if _, ok := c.getRepoProvider(urlInfo.Host); !ok || urlInfo.Host == "" {
+		return nil, errors.Errorf("unsupported host of the repository: %q", urlInfo.Host)
+	}
+
+	nameOwner, err := repoInfo.getNameOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	repoInfo.Owner = nameOwner
+
+	nameRepo, err := repoInfo.getNameRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	repoInfo.Name = nameRepo
+
+	// Update other field
+	if err := repoInfo.UpdateContext(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to update repository info")
+	}
+
+	return repoInfo, nil
+}
+
+// getContent is the implementation of GetContentURLContext. It also returns true
+// if the content came from the cache. Such as the fresh entry or "304 Not
+// Modified".
+func (c *Client) getContent(ctx context.Context, urlTarget string) ([]byte, bool, error) {
+	urlParsed, err := url.Parse(urlTarget)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse URL before request")
+	}
+
+	key := "content:" + urlParsed.String()
+	entry := c.Cache.load(key)
+	content := []byte{}
+
+	if c.Cache.isFresh(entry) && entry.decode(&content) == nil {
+		return content, true, nil
+	}
+
+	if err := c.waitRateLimit(ctx, urlParsed.Hostname()); err != nil {
+		return nil, false, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlParsed.String(), nil)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to create request")
+	}
+
+	if etag := entry.getETag(); etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+
+	response, err := c.getHTTPClient().Do(request)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to fetch contents from the URL")
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotModified && entry.decode(&content) == nil {
+		c.Cache.store(key, entry.ETag, content)
+
+		return content, true, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, false, errors.Errorf("failed to featch. returned status: %v",
+			response.StatusCode)
+	}
+
+	buf := new(bytes.Buffer)
+
+	// Copy data from the response to the buffer
+	if _, err = IOCopy(buf, response.Body); err != nil {
+		return nil, false, errors.Wrap(err, "failed to copy response data")
+	}
+
+	c.Cache.store(key, response.Header.Get("ETag"), buf.Bytes())
+
+	return buf.Bytes(), false, nil
+}
+
+func (c *Client) getAliases() *AliasRegistry {
+	if c.Aliases == nil {
+		return DefaultAliasRegistry()
+	}
+
+	return c.Aliases
+}
+
+func (c *Client) getCodecovBaseURL() string {
+	if c.CodecovBaseURL == "" {
+		return urlCodecovDefault
+	}
+
+	return c.CodecovBaseURL
+}
+
+func (c *Client) getCoverallsBaseURL() string {
+	if c.CoverallsBaseURL == "" {
+		return urlCoverallsDefault
+	}
+
+	return c.CoverallsBaseURL
+}
+
+func (c *Client) getGravityModel() *GravityModel {
+	if c.GravityModel == nil {
+		return DefaultGravityModel()
+	}
+
+	return c.GravityModel
+}
+
+func (c *Client) getGoProxyBaseURL() string {
+	if c.GoProxyBaseURL == "" {
+		return urlGoProxyDefault
+	}
+
+	return strings.TrimSuffix(c.GoProxyBaseURL, "/")
+}
+
+func (c *Client) getHealthWindow() time.Duration {
+	if c.HealthWindow <= 0 {
+		return daysHealthWindow * hoursPerDay * time.Hour
+	}
+
+	return c.HealthWindow
+}
+
+func (c *Client) getHTTPClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+
+	return c.HTTPClient
+}
+
+func (c *Client) getGitHubBaseURL() string {
+	if c.GitHubBaseURL == "" {
+		return urlGitHubAPIDefault
+	}
+
+	return c.GitHubBaseURL
+}
+
+func (c *Client) getLevel() string {
+	if c.Level == "" {
+		return LevelPackage
+	}
+
+	return c.Level
+}
+
+func (c *Client) getPkgGoDevBaseURL() string {
+	if c.PkgGoDevBaseURL == "" {
+		return urlPkgGoDevDefault
+	}
+
+	return c.PkgGoDevBaseURL
+}
+
+func (c *Client) getRawContentBaseURL() string {
+	if c.RawContentBaseURL == "" {
+		return urlRawContentDefault
+	}
+
+	return c.RawContentBaseURL
+}
+
+func (c *Client) getReportCardBaseURL() string {
+	if c.ReportCardBaseURL == "" {
+		return urlReportCardDefault
+	}
+
+	return c.ReportCardBaseURL
+}
+
+// Credential returns the GitHub token to use and its source.
+//
+// The Token field of the client has priority, then GithubToken. If both are
+// empty, the requests are unauthenticated. The token is never read from the
+// environment implicitly. Use ResolveGitHubToken to set Token from there.
+func (c *Client) Credential() Credential {
+	if c.Token != "" {
+		return Credential{Token: c.Token, Source: TokenSourceArgument}
+	}
+
+	if GithubToken != "" {
+		return Credential{Token: GithubToken, Source: TokenSourceGlobal}
+	}
+
+	return Credential{Source: TokenSourceNone}
+}
+
+func (c *Client) getToken() string {
+	return c.Credential().Token
+}
+
+// newGitHubClient returns a client of go-github that requests to GitHubBaseURL.
+// If a token is available, the requests will be authenticated.
+func (c *Client) newGitHubClient(ctx context.Context) (*github.Client, error) {
+	httpClient := c.getHTTPClient()
+
+	if token := c.getToken(); token != "" {
+		// Let oauth2 use our HTTP client as the underlying transport
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		)
+
+		httpClient = oauth2.NewClient(ctx, ts)
+	}
+
+	client := github.NewClient(httpClient)
+	urlBase := c.getGitHubBaseURL()
+
+	// go-github requires the base URL to have a trailing slash
+	if !strings.HasSuffix(urlBase, "/") {
+		urlBase += "/"
+	}
+
+	parsed, err := url.Parse(urlBase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse GitHub API base URL")
+	}
+
+	client.BaseURL = parsed
+
+	return client, nil
+}
+
+// newPkgGoDevClient returns a scraping client of pkg.go.dev which requests to
+// PkgGoDevBaseURL.
+//
+// Since the scraper does not support context, the requests are bound to ctx via
+// the transport of the HTTP client.
+func (c *Client) newPkgGoDevClient(ctx context.Context) pkgGoDevClient {
+	urlBase := c.getPkgGoDevBaseURL()
+
+	// Copy to avoid the scraper modifying our client
+	httpClient := *c.getHTTPClient()
+
+	httpClient.Transport = &contextTransport{
+		ctx:  ctx,
+		base: httpClient.Transport,
+	}
+
+	return pkggodevclient.New(
+		pkggodevclient.WithBaseURL(strings.TrimSuffix(urlBase, "/")),
+		pkggodevclient.WithHTTPClient(&httpClient),
+	)
+}
+
+// observeRateLimit adapts the rate limit of the host from the response of GitHub
+// API.
+func (c *Client) observeRateLimit(host string, resp *github.Response) {
+	if c.RateLimiter == nil || resp == nil || resp.Rate.Limit == 0 {
+		return
+	}
+
+	c.RateLimiter.Observe(host, resp.Rate.Remaining, resp.Rate.Reset.Time)
+}
+
+// waitRateLimit blocks until a request to the host is allowed by the rate
+// limiter or ctx is done.
+func (c *Client) waitRateLimit(ctx context.Context, host string) error {
+	if c.RateLimiter == nil {
+		return ctx.Err()
+	}
+
+	return c.RateLimiter.Wait(ctx, host)
+}
+
+// pkgGoDevClient is the method set of the unexported client type of
+// pkggodevclient package that we use.
+type pkgGoDevClient interface {
+	ImportedBy(req pkggodevclient.ImportedByRequest) (*pkggodevclient.ImportedBy, error)
+	DescribePackage(req pkggodevclient.DescribePackageRequest) (*pkggodevclient.Package, error)
+}
+
+// ============================================================================
+//  Type: contextTransport
+// ============================================================================
+
+// contextTransport is an implementation of http.RoundTripper that binds all the
+// requests to ctx.
+type contextTransport struct {
+	ctx  context.Context   // Context to bind. The scraper has no way to pass it per request
+	base http.RoundTripper // Underlying transport. http.DefaultTransport is used if nil
+}
+
+// RoundTrip is an implementation of http.RoundTripper.
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(req.WithContext(t.ctx))
+}
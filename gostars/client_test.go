@@ -0,0 +1,133 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Client
+// ----------------------------------------------------------------------------
+
+func TestClient_GetContentURL(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	content, err := client.GetContentURL(server.URL + "/content/hello.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, world!", string(content))
+
+	_, err = client.GetContentURL(server.URL + "/content/unknown.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "returned status: 404")
+}
+
+func TestClient_GetContentURLContext_deadline(t *testing.T) {
+	// Server that hangs until the request is canceled
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := gostars.NewClient()
+	client.HTTPClient = server.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetContentURLContext(ctx, server.URL)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_context_canceled(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetContentURLContext(ctx, server.URL+"/content/hello.txt")
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = client.NewPkgInfoContext(ctx, "github.com/KEINOS/dev-go")
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = client.NewRepoInfoContext(ctx, "https://github.com/KEINOS/dev-go")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPkgInfo_UpdateImportedByContext_canceled(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	pkgInfo, err := client.NewPkgInfo("github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The scraper of pkg.go.dev must also be canceled
+	err = pkgInfo.UpdateImportedByContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClient_NewPkgInfo(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	pkgInfo, err := client.NewPkgInfo("github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, "github.com/KEINOS/dev-go", pkgInfo.Name)
+	assert.Equal(t, "https://github.com/KEINOS/dev-go", pkgInfo.Repository)
+	assert.Equal(t, 2, pkgInfo.ImportedBy)
+}
+
+func TestClient_NewRepoInfo(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	repoInfo, err := client.NewRepoInfo("https://github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, "KEINOS", repoInfo.Owner)
+	assert.Equal(t, "dev-go", repoInfo.Name)
+	assert.Equal(t, "Dockerfile for Go development", repoInfo.Description)
+	assert.Equal(t, 10, repoInfo.Stars)
+	assert.Equal(t, 3, repoInfo.Forks)
+	assert.Equal(t, 2, repoInfo.Followers)
+
+	// Unknown repository
+	repoInfo, err = client.NewRepoInfo("https://github.com/KEINOS/undefined")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to update repository info")
+	assert.Nil(t, repoInfo)
+}
+
+func TestClient_NewRepoInfo_with_token(t *testing.T) {
+	var authHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+
+		fmt.Fprint(w, `{"name": "dev-go"}`)
+	}))
+	defer server.Close()
+
+	client := gostars.NewClient()
+
+	client.HTTPClient = server.Client()
+	client.GitHubBaseURL = server.URL // without trailing slash
+	client.Token = "dummy-token"
+
+	_, err := client.NewRepoInfo("https://github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer dummy-token", authHeader)
+}
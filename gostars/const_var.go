@@ -10,16 +10,30 @@ import "io"
 //  Constants
 // ----------------------------------------------------------------------------
 
-const urlAwesomeGoDefault = "https://raw.githubusercontent.com/avelino/awesome-go/main/README.md"
+const (
+	urlAwesomeGoDefault  = "https://raw.githubusercontent.com/avelino/awesome-go/main/README.md"
+	urlGitHubAPIDefault  = "https://api.github.com/"
+	urlPkgGoDevDefault   = "https://pkg.go.dev"
+	urlReportCardDefault = "https://goreportcard.com"
+	urlCodecovDefault    = "https://api.codecov.io/api/v2"
+	urlCoverallsDefault  = "https://coveralls.io"
+	urlRawContentDefault = "https://raw.githubusercontent.com"
+	urlGoProxyDefault    = "https://proxy.golang.org"
+	concurrencyDefault   = 4
+)
 
 // ----------------------------------------------------------------------------
 //  Variables
 // ----------------------------------------------------------------------------
 
 // URLAwesomeGo is the URL of Awesome-Go's README.md. Which is the markdown
-// file of the awesome Go packages.
+// file of the awesome Go packages. NewAwesomeGo downloads the list from here.
 var URLAwesomeGo = urlAwesomeGoDefault
 
+// DefaultClient is the Client used by the package-level functions such as
+// NewPkgInfo, NewRepoInfo and GetContentURL.
+var DefaultClient = NewClient()
+
 // GithubToken is a personal access token for the GitHub API that must be assigned
 // by the caller and must not be hard-coded in the source code.
 var GithubToken string
@@ -28,6 +42,7 @@ var GithubToken string
 var IOCopy = io.Copy
 
 // URLAliases are a mapping between the site URL and the actual URL of the GitHub repository.
+// They are the built-in aliases of DefaultAliasRegistry.
 var URLAliases = map[string]string{
 	"https://joe-bot.net/": "https://github.com/go-joe/joe",
 }
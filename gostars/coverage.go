@@ -0,0 +1,222 @@
+package gostars
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Providers of the code coverage. See CoverageInfo.
+const (
+	ProviderCodecov   = "codecov"
+	ProviderCoveralls = "coveralls"
+	ProviderBadge     = "badge"
+)
+
+// coverageServices maps the host of the repository to the service name used in
+// the API paths of Codecov and Coveralls.
+var coverageServices = map[string]string{
+	"github.com":    "github",
+	"gitlab.com":    "gitlab",
+	"bitbucket.org": "bitbucket",
+}
+
+var (
+	// reImageMarkdown matches the images in markdown. Such as ![alt](url "title").
+	reImageMarkdown = regexp.MustCompile(`!\[([^\]]*)\]\(\s*(\S+?)(?:\s+"[^"]*")?\s*\)`)
+	// reImageHTML matches the src of the img tags in HTML.
+	reImageHTML = regexp.MustCompile(`(?i)<img\s[^>]*src=["']([^"']+)["']`)
+	// rePercentage matches the percentage in the text of the SVG badges. Such as
+	// "<text>85%</text>".
+	rePercentage = regexp.MustCompile(`>\s*(\d+(?:\.\d+)?)\s*%\s*<`)
+)
+
+// ============================================================================
+//  Type: CoverageInfo
+// ============================================================================
+
+// CoverageInfo holds the code coverage rate of a repository.
+type CoverageInfo struct {
+	Repo     string  `json:"repo"`     // URL of the repository
+	Provider string  `json:"provider"` // Where the coverage was found. "codecov", "coveralls" or "badge"
+	Coverage float64 `json:"coverage"` // Coverage rate in percent. 0..100
+	URL      string  `json:"url"`      // URL of the source of the coverage
+}
+
+// ============================================================================
+//  Constructor
+// ============================================================================
+
+// NewCoverageInfo returns the code coverage rate of the repository. It is a
+// wrapper of DefaultClient.NewCoverageInfo.
+func NewCoverageInfo(ctx context.Context, urlRepo string) (*CoverageInfo, error) {
+	return DefaultClient.NewCoverageInfo(ctx, urlRepo)
+}
+
+// NewCoverageInfo returns the code coverage rate of the repository such as
+// "https://github.com/KEINOS/gostars".
+//
+// The coverage is searched in the following order and the first one found is
+// returned:
+//
+//  1. Codecov API (CodecovBaseURL)
+//  2. Coveralls API (CoverallsBaseURL)
+//  3. Coverage badge in the README.md of the repository (RawContentBaseURL)
+//
+// The badge is an image in the README whose alternative text or URL contains
+// "cov". Such as "codecov" and "coverage". The rate is read from the SVG of the
+// badge. Only GitHub repositories are supported for the badge.
+func (c *Client) NewCoverageInfo(ctx context.Context, urlRepo string) (*CoverageInfo, error) {
+	urlInfo, err := NewURLInfo(urlRepo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get URL information")
+	}
+
+	// Such as "group/sub" and "repo" of the nested groups of GitLab
+	owner, name := urlInfo.Owner(), urlInfo.Repo()
+	if owner == "" || name == "" {
+		return nil, errors.New("invalid URL format. missing repo owner and/or repo name")
+	}
+
+	if service, ok := coverageServices[urlInfo.Host]; ok {
+		if info, err := c.getCoverageCodecov(ctx, service, owner, name); err == nil {
+			info.Repo = urlRepo
+
+			return info, nil
+		}
+
+		if info, err := c.getCoverageCoveralls(ctx, service, owner, name); err == nil {
+			info.Repo = urlRepo
+
+			return info, nil
+		}
+	}
+
+	if urlInfo.IsRepoGitHub() {
+		if info, err := c.getCoverageBadge(ctx, owner, name); err == nil {
+			info.Repo = urlRepo
+
+			return info, nil
+		}
+	}
+
+	return nil, errors.Errorf("no coverage found for %s on Codecov, Coveralls nor README badge", urlRepo)
+}
+
+// ============================================================================
+//  Private Methods
+// ============================================================================
+
+func (c *Client) getCoverageBadge(ctx context.Context, owner, name string) (*CoverageInfo, error) {
+	urlBase := strings.TrimSuffix(c.getRawContentBaseURL(), "/")
+
+	readme, err := c.GetContentURLContext(ctx, urlBase+"/"+owner+"/"+name+"/HEAD/README.md")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get README")
+	}
+
+	for _, urlBadge := range findCoverageBadges(string(readme)) {
+		svg, err := c.GetContentURLContext(ctx, urlBadge)
+		if err != nil {
+			continue
+		}
+
+		match := rePercentage.FindSubmatch(svg)
+		if match == nil {
+			continue
+		}
+
+		coverage, err := strconv.ParseFloat(string(match[1]), 64)
+		if err != nil {
+			continue
+		}
+
+		return &CoverageInfo{Provider: ProviderBadge, Coverage: coverage, URL: urlBadge}, nil
+	}
+
+	return nil, errors.New("no coverage badge in README")
+}
+
+func (c *Client) getCoverageCodecov(ctx context.Context, service, owner, name string) (*CoverageInfo, error) {
+	urlBase := strings.TrimSuffix(c.getCodecovBaseURL(), "/")
+	// Codecov names the subgroups of GitLab with ":". Such as "group:sub"
+	urlAPI := urlBase + "/" + service + "/" + strings.ReplaceAll(owner, "/", ":") + "/repos/" + name + "/"
+
+	content, err := c.GetContentURLContext(ctx, urlAPI)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get coverage from Codecov")
+	}
+
+	var response struct {
+		Totals *struct {
+			Coverage *float64 `json:"coverage"`
+		} `json:"totals"`
+	}
+
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, errors.Wrap(err, "malformed response of Codecov")
+	}
+
+	if response.Totals == nil || response.Totals.Coverage == nil {
+		return nil, errors.New("no coverage on Codecov")
+	}
+
+	return &CoverageInfo{Provider: ProviderCodecov, Coverage: *response.Totals.Coverage, URL: urlAPI}, nil
+}
+
+func (c *Client) getCoverageCoveralls(ctx context.Context, service, owner, name string) (*CoverageInfo, error) {
+	urlBase := strings.TrimSuffix(c.getCoverallsBaseURL(), "/")
+	urlAPI := urlBase + "/" + service + "/" + owner + "/" + name + ".json"
+
+	content, err := c.GetContentURLContext(ctx, urlAPI)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get coverage from Coveralls")
+	}
+
+	var response struct {
+		CoveredPercent *float64 `json:"covered_percent"`
+	}
+
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, errors.Wrap(err, "malformed response of Coveralls")
+	}
+
+	if response.CoveredPercent == nil {
+		return nil, errors.New("no coverage on Coveralls")
+	}
+
+	return &CoverageInfo{Provider: ProviderCoveralls, Coverage: *response.CoveredPercent, URL: urlAPI}, nil
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// findCoverageBadges returns the absolute URLs of the images in the README
+// whose alternative text or URL contains "cov".
+func findCoverageBadges(readme string) []string {
+	found := []string{}
+
+	isCandidate := func(text, urlImage string) bool {
+		return strings.HasPrefix(urlImage, "http") &&
+			strings.Contains(strings.ToLower(text+" "+urlImage), "cov")
+	}
+
+	for _, match := range reImageMarkdown.FindAllStringSubmatch(readme, -1) {
+		if isCandidate(match[1], match[2]) {
+			found = append(found, match[2])
+		}
+	}
+
+	for _, match := range reImageHTML.FindAllStringSubmatch(readme, -1) {
+		if isCandidate(match[0], match[1]) {
+			found = append(found, match[1])
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,107 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  CoverageInfo
+// ----------------------------------------------------------------------------
+
+// addCoverageHandlers adds the mocks of Codecov, Coveralls and the raw contents
+// of GitHub. Each serves a different repository to test the fallbacks.
+func addCoverageHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/codecov/github/KEINOS/repos/dev-go/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "dev-go", "totals": {"files": 10, "coverage": 85.5}}`)
+	})
+
+	mux.HandleFunc("/coveralls/github/KEINOS/coveralls-only.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"repo_name": "KEINOS/coveralls-only", "covered_percent": 70.25}`)
+	})
+
+	mux.HandleFunc("/codecov/gitlab/group:sub/repos/nested/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "nested", "totals": {"files": 3, "coverage": 90}}`)
+	})
+
+	mux.HandleFunc("/coveralls/gitlab/group/sub/coveralls-only.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"repo_name": "group/sub/coveralls-only", "covered_percent": 55.5}`)
+	})
+
+	mux.HandleFunc("/raw/KEINOS/badge-only/HEAD/README.md", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "# badge-only\n\n"+
+			"[![go1.17+](http://%[1]s/badge/go.svg)](https://golang.org/)\n"+
+			"[![codecov](http://%[1]s/badge/coverage.svg \"Coverage\")](https://codecov.io/)\n", r.Host)
+	})
+
+	mux.HandleFunc("/badge/coverage.svg", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<svg><g><text x="5">coverage</text><text x="80">64%</text></g></svg>`)
+	})
+}
+
+func TestClient_NewCoverageInfo(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	for _, test := range []struct {
+		urlRepo  string
+		provider string
+		coverage float64
+	}{
+		{"https://github.com/KEINOS/dev-go", gostars.ProviderCodecov, 85.5},
+		{"https://github.com/KEINOS/coveralls-only", gostars.ProviderCoveralls, 70.25},
+		{"https://github.com/KEINOS/badge-only", gostars.ProviderBadge, 64},
+		// Nested groups of GitLab
+		{"https://gitlab.com/group/sub/nested", gostars.ProviderCodecov, 90},
+		{"https://gitlab.com/group/sub/coveralls-only/-/tree/main/cmd", gostars.ProviderCoveralls, 55.5},
+	} {
+		info, err := client.NewCoverageInfo(context.Background(), test.urlRepo)
+		require.NoError(t, err, test.urlRepo)
+
+		assert.Equal(t, test.urlRepo, info.Repo)
+		assert.Equal(t, test.provider, info.Provider, test.urlRepo)
+		assert.Equal(t, test.coverage, info.Coverage, test.urlRepo)
+	}
+
+	// No coverage anywhere
+	_, err := client.NewCoverageInfo(context.Background(), "https://github.com/foo/bar")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no coverage found for https://github.com/foo/bar")
+
+	// Missing repo name
+	_, err = client.NewCoverageInfo(context.Background(), "https://github.com/foo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing repo owner and/or repo name")
+}
+
+func TestClient_ScorePackage_coverage(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	client.GravityModel = &gostars.GravityModel{
+		Weights: map[string]float64{gostars.DimCoverage: 2},
+	}
+
+	score, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	require.NotNil(t, score.Coverage)
+	assert.Equal(t, 171, score.Gravity)
+	assert.Empty(t, score.Warnings)
+
+	// Not found does not fail the score
+	client.CodecovBaseURL = server.URL + "/unknown"
+
+	score, err = client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Nil(t, score.Coverage)
+	require.Len(t, score.Warnings, 1)
+	assert.Contains(t, score.Warnings[0], "no coverage found")
+}
@@ -0,0 +1,219 @@
+package gostars
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of the GitHub token. See ResolveGitHubToken for the order.
+const (
+	TokenSourceNone     = ""                                  // No token was found
+	TokenSourceArgument = "argument"                          // Given by the caller. Such as a flag
+	TokenSourceGlobal   = "gostars.GithubToken variable"      // GithubToken package variable. See Client.Credential
+	TokenSourceEnvGH    = "GITHUB_TOKEN environment variable" // $GITHUB_TOKEN
+	TokenSourceEnvGHCLI = "GH_TOKEN environment variable"     // $GH_TOKEN
+	TokenSourceGHConfig = "gh CLI config"                     // hosts.yml of GitHub CLI
+	TokenSourceNetrc    = "netrc"                             // ~/.netrc
+)
+
+// hostGitHub is the host name to search in the gh CLI config and netrc.
+const hostGitHub = "github.com"
+
+// ============================================================================
+//  Type: Credential
+// ============================================================================
+
+// Credential is a GitHub token with the source where it was found.
+//
+// To avoid leaking the token, String and GoString never print the token.
+type Credential struct {
+	Token  string // GitHub personal access token
+	Source string // Where the token was found. One of TokenSource* constants
+	Path   string // Path of the file if the source is a file
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// ResolveGitHubToken searches the GitHub token in the following order and
+// returns the first one found.
+//
+//  1. token given as the argument (If not empty)
+//  2. GITHUB_TOKEN environment variable
+//  3. GH_TOKEN environment variable
+//  4. "oauth_token" of github.com in hosts.yml of GitHub CLI (gh)
+//  5. "password" of api.github.com or github.com machine in ~/.netrc
+//
+// The location of hosts.yml follows gh CLI. Such as $GH_CONFIG_DIR/hosts.yml
+// or ~/.config/gh/hosts.yml. The location of netrc can be changed by $NETRC.
+// The files that fail to read or parse are skipped. If no token was found, the
+// Source of the returned Credential is TokenSourceNone.
+func ResolveGitHubToken(token string) Credential {
+	if token != "" {
+		return Credential{Token: token, Source: TokenSourceArgument}
+	}
+
+	if token = os.Getenv("GITHUB_TOKEN"); token != "" {
+		return Credential{Token: token, Source: TokenSourceEnvGH}
+	}
+
+	if token = os.Getenv("GH_TOKEN"); token != "" {
+		return Credential{Token: token, Source: TokenSourceEnvGHCLI}
+	}
+
+	if path := getPathGHConfig(); path != "" {
+		if token = readTokenGHConfig(path); token != "" {
+			return Credential{Token: token, Source: TokenSourceGHConfig, Path: path}
+		}
+	}
+
+	if path := getPathNetrc(); path != "" {
+		if token = readTokenNetrc(path); token != "" {
+			return Credential{Token: token, Source: TokenSourceNetrc, Path: path}
+		}
+	}
+
+	return Credential{Source: TokenSourceNone}
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// GoString is an implementation of fmt.GoStringer. It hides the token.
+func (c Credential) GoString() string {
+	return "gostars.Credential{" + c.String() + "}"
+}
+
+// String is an implementation of fmt.Stringer. It returns the source of the
+// token and never the token itself.
+func (c Credential) String() string {
+	switch {
+	case c.Source == TokenSourceNone:
+		return "no token"
+	case c.Path != "":
+		return c.Source + " (" + c.Path + ")"
+	default:
+		return c.Source
+	}
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// getPathGHConfig returns the path of hosts.yml of GitHub CLI. It returns an
+// empty string if the home directory is unknown.
+func getPathGHConfig() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml")
+	}
+
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml")
+	}
+
+	if dir := os.Getenv("AppData"); runtime.GOOS == "windows" && dir != "" {
+		return filepath.Join(dir, "GitHub CLI", "hosts.yml")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".config", "gh", "hosts.yml")
+}
+
+// getPathNetrc returns the path of netrc file. It returns an empty string if
+// the home directory is unknown.
+func getPathNetrc() string {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	if runtime.GOOS == "windows" {
+		return filepath.Join(home, "_netrc")
+	}
+
+	return filepath.Join(home, ".netrc")
+}
+
+// readTokenGHConfig returns the token of github.com from hosts.yml of GitHub
+// CLI. If the token is stored in the keyring of the OS, it returns an empty
+// string.
+func readTokenGHConfig(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	type hostConfig struct {
+		OAuthToken string `yaml:"oauth_token"`
+		User       string `yaml:"user"`
+		Users      map[string]struct {
+			OAuthToken string `yaml:"oauth_token"`
+		} `yaml:"users"`
+	}
+
+	hosts := map[string]hostConfig{}
+
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return ""
+	}
+
+	host := hosts[hostGitHub]
+	if host.OAuthToken != "" {
+		return host.OAuthToken
+	}
+
+	// Multi-account format of the newer gh CLI
+	return host.Users[host.User].OAuthToken
+}
+
+// readTokenNetrc returns the password of "api.github.com" or "github.com"
+// machine in the netrc file. The former has priority.
+func readTokenNetrc(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	passwords := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Split(bufio.ScanWords)
+
+	machine := ""
+
+	for scanner.Scan() {
+		switch scanner.Text() {
+		case "machine":
+			if scanner.Scan() {
+				machine = scanner.Text()
+			}
+		case "default":
+			machine = ""
+		case "password":
+			if scanner.Scan() && machine != "" {
+				passwords[machine] = scanner.Text()
+			}
+		}
+	}
+
+	if password, ok := passwords["api."+hostGitHub]; ok {
+		return password
+	}
+
+	return passwords[hostGitHub]
+}
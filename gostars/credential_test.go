@@ -0,0 +1,171 @@
+package gostars_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Credential
+// ----------------------------------------------------------------------------
+
+// isolateCredentials clears the environment variables of GitHub token and
+// points the config files of gh CLI and netrc to a temp directory. It returns
+// the paths of hosts.yml and netrc which do not exist yet.
+func isolateCredentials(t *testing.T) (string, string) {
+	t.Helper()
+
+	dirTemp := t.TempDir()
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GH_CONFIG_DIR", dirTemp)
+	t.Setenv("NETRC", filepath.Join(dirTemp, "netrc"))
+
+	return filepath.Join(dirTemp, "hosts.yml"), filepath.Join(dirTemp, "netrc")
+}
+
+func TestResolveGitHubToken(t *testing.T) {
+	pathGHConfig, pathNetrc := isolateCredentials(t)
+
+	// No token
+	cred := gostars.ResolveGitHubToken("")
+	assert.Equal(t, gostars.TokenSourceNone, cred.Source)
+	assert.Empty(t, cred.Token)
+
+	// netrc
+	require.NoError(t, os.WriteFile(pathNetrc, []byte(`
+machine example.com login foo password bar
+machine github.com
+  login octocat
+  password token-from-netrc
+default login anonymous password anonymous
+`), 0o600))
+
+	cred = gostars.ResolveGitHubToken("")
+	assert.Equal(t, "token-from-netrc", cred.Token)
+	assert.Equal(t, gostars.TokenSourceNetrc, cred.Source)
+	assert.Equal(t, pathNetrc, cred.Path)
+
+	// gh CLI config
+	require.NoError(t, os.WriteFile(pathGHConfig, []byte(`
+github.com:
+    user: octocat
+    oauth_token: token-from-gh
+    git_protocol: https
+`), 0o600))
+
+	cred = gostars.ResolveGitHubToken("")
+	assert.Equal(t, "token-from-gh", cred.Token)
+	assert.Equal(t, gostars.TokenSourceGHConfig, cred.Source)
+
+	// Environment variables
+	t.Setenv("GH_TOKEN", "token-from-gh-env")
+
+	cred = gostars.ResolveGitHubToken("")
+	assert.Equal(t, "token-from-gh-env", cred.Token)
+	assert.Equal(t, gostars.TokenSourceEnvGHCLI, cred.Source)
+
+	t.Setenv("GITHUB_TOKEN", "token-from-github-env")
+
+	cred = gostars.ResolveGitHubToken("")
+	assert.Equal(t, "token-from-github-env", cred.Token)
+	assert.Equal(t, gostars.TokenSourceEnvGH, cred.Source)
+
+	// Argument has the highest priority
+	cred = gostars.ResolveGitHubToken("token-from-arg")
+	assert.Equal(t, "token-from-arg", cred.Token)
+	assert.Equal(t, gostars.TokenSourceArgument, cred.Source)
+}
+
+func TestResolveGitHubToken_gh_multi_account(t *testing.T) {
+	pathGHConfig, _ := isolateCredentials(t)
+
+	require.NoError(t, os.WriteFile(pathGHConfig, []byte(`
+github.com:
+    git_protocol: https
+    users:
+        octocat:
+            oauth_token: token-of-octocat
+        monalisa:
+            oauth_token: token-of-monalisa
+    user: monalisa
+`), 0o600))
+
+	cred := gostars.ResolveGitHubToken("")
+	assert.Equal(t, "token-of-monalisa", cred.Token, "it should use the token of the active user")
+}
+
+func TestResolveGitHubToken_netrc_api_host(t *testing.T) {
+	_, pathNetrc := isolateCredentials(t)
+
+	require.NoError(t, os.WriteFile(pathNetrc, []byte(
+		"machine github.com password token-of-web\nmachine api.github.com password token-of-api\n",
+	), 0o600))
+
+	cred := gostars.ResolveGitHubToken("")
+	assert.Equal(t, "token-of-api", cred.Token, "api.github.com should have priority")
+}
+
+func TestResolveGitHubToken_malformed_config(t *testing.T) {
+	pathGHConfig, _ := isolateCredentials(t)
+
+	require.NoError(t, os.WriteFile(pathGHConfig, []byte("github.com: [unclosed"), 0o600))
+
+	cred := gostars.ResolveGitHubToken("")
+	assert.Equal(t, gostars.TokenSourceNone, cred.Source, "malformed config should be skipped")
+}
+
+func TestCredential_String(t *testing.T) {
+	cred := gostars.Credential{
+		Token:  "secret-token",
+		Source: gostars.TokenSourceNetrc,
+		Path:   "/home/octocat/.netrc",
+	}
+
+	for _, out := range []string{
+		cred.String(),
+		fmt.Sprint(cred),
+		fmt.Sprintf("%v", cred),
+		fmt.Sprintf("%#v", cred),
+	} {
+		assert.NotContains(t, out, "secret-token", "it should never print the token")
+		assert.Contains(t, out, "netrc (/home/octocat/.netrc)")
+	}
+
+	assert.Equal(t, "no token", gostars.Credential{}.String())
+}
+
+func TestClient_Credential(t *testing.T) {
+	_, pathNetrc := isolateCredentials(t)
+
+	oldGithubToken := gostars.GithubToken
+	defer func() {
+		gostars.GithubToken = oldGithubToken
+	}()
+
+	gostars.GithubToken = ""
+
+	require.NoError(t, os.WriteFile(pathNetrc, []byte("machine github.com password token-from-netrc"), 0o600))
+
+	client := gostars.NewClient()
+
+	// Never from the environment implicitly
+	assert.Equal(t, gostars.Credential{Source: gostars.TokenSourceNone}, client.Credential())
+
+	// GithubToken set by the caller
+	gostars.GithubToken = "token-from-var"
+	assert.Equal(t, gostars.Credential{Token: "token-from-var", Source: gostars.TokenSourceGlobal}, client.Credential())
+	assert.Equal(t, "gostars.GithubToken variable", client.Credential().String())
+
+	// Token of the client has the highest priority
+	client.Token = "token-of-client"
+	assert.Equal(t, gostars.Credential{Token: "token-of-client", Source: gostars.TokenSourceArgument}, client.Credential())
+}
@@ -3,6 +3,8 @@ package gostars_test
 import (
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 
 	"github.com/KEINOS/gostars/gostars"
@@ -37,16 +39,20 @@ func ExampleGetAttractionGravity() {
 }
 
 func ExampleGetContentURL() {
-	rawContent, err := gostars.GetContentURL("https://github.com/KEINOS")
+	// Mock server instead of the real host. See newFakeHandler
+	server := httptest.NewServer(newFakeHandler())
+	defer server.Close()
+
+	defer useDefaultClient(newFakeClient(server))()
+
+	rawContent, err := gostars.GetContentURL(server.URL + "/content/hello.txt")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	source := string(rawContent)
+	fmt.Println(string(rawContent))
 
-	fmt.Println(strings.Contains(source, "Profile of KEINOS"))
-
-	// Output: true
+	// Output: Hello, world!
 }
 
 func ExampleGetURLGitHub() {
@@ -67,26 +73,64 @@ func ExampleHash256() {
 	// Output: af2bdbe1aa9b6ec1e2ade1d694f41fc71a831d0268e9891562113d8a62add1bf
 }
 
+func ExampleNewClient() {
+	// Mock server of GitHub API
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "gostars", "stargazers_count": 12}`)
+	}))
+	defer server.Close()
+
+	// Point the client to the mock server
+	client := gostars.NewClient()
+
+	client.HTTPClient = server.Client()
+	client.GitHubBaseURL = server.URL
+
+	repoInfo, err := client.NewRepoInfo("https://github.com/KEINOS/gostars")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Name repo:", repoInfo.Name)
+	fmt.Println("Stars:", repoInfo.Stars)
+
+	// Output:
+	// Name repo: gostars
+	// Stars: 12
+}
+
 func ExampleNewPkgInfo() {
+	// Mock of pkg.go.dev instead of the real one. See newFakeHandler
+	server := httptest.NewServer(newFakeHandler())
+	defer server.Close()
+
+	defer useDefaultClient(newFakeClient(server))()
+
 	// Get package info from "pkg.go.dev"
-	pkgInfo, err := gostars.NewPkgInfo("github.com/KEINOS/go-utiles/util")
+	pkgInfo, err := gostars.NewPkgInfo("github.com/KEINOS/dev-go")
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// Get number of packages that uses this package
-	if pkgInfo.ImportedBy > 3 {
-		fmt.Println("This package has been used by 3 or more packages.")
+	if pkgInfo.ImportedBy >= 2 {
+		fmt.Println("This package has been used by 2 or more packages.")
 	}
 
 	fmt.Println("The URL of the repository:", pkgInfo.Repository)
 
 	// Output:
-	// This package has been used by 3 or more packages.
-	// The URL of the repository: https://github.com/KEINOS/go-utiles
+	// This package has been used by 2 or more packages.
+	// The URL of the repository: https://github.com/KEINOS/dev-go
 }
 
 func ExampleNewRepoInfo() {
+	// Mock of GitHub API instead of the real one. See newFakeHandler
+	server := httptest.NewServer(newFakeHandler())
+	defer server.Close()
+
+	defer useDefaultClient(newFakeClient(server))()
+
 	repoInfo, err := gostars.NewRepoInfo("https://github.com/KEINOS/dev-go")
 	if err != nil {
 		log.Fatal(err)
@@ -175,6 +219,76 @@ Hello, world!
 	// </body>
 }
 
+func ExampleParseAwesomeGo() {
+	markdown := `
+# Awesome Go
+
+## Contents
+
+- [Actor Model](#actor-model)
+- [Database](#database)
+
+## Actor Model
+
+- [Ergo](https://github.com/ergo-services/ergo) - An actor-based Framework.
+
+## Database
+
+### Caches
+
+- [bcache](https://github.com/iwanbk/bcache) - Eventually consistent distributed in-memory cache.
+
+### Databases Implemented in Go
+
+- [joe](https://joe-bot.net/) - A bot library. (For alias sample)
+`
+
+	awesome, err := gostars.ParseAwesomeGo([]byte(markdown))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Categories:", strings.Join(awesome.Categories(), ", "))
+
+	for _, entry := range awesome.Entries("database") {
+		fmt.Printf("%s: %s (%s) %s\n", entry.Category, entry.Name, entry.URL, entry.Description)
+	}
+
+	// Output:
+	// Categories: Actor Model, Database, Caches, Databases Implemented in Go
+	// Caches: bcache (https://github.com/iwanbk/bcache) Eventually consistent distributed in-memory cache.
+	// Databases Implemented in Go: joe (https://github.com/go-joe/joe) A bot library. (For alias sample)
+}
+
+func ExampleParseGoMod() {
+	goMod := `
+module github.com/KEINOS/sample
+
+go 1.17
+
+require github.com/pkg/errors v0.9.1
+
+require (
+	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+`
+
+	requirements, err := gostars.ParseGoMod([]byte(goMod))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, requirement := range requirements {
+		fmt.Println(requirement.Path, requirement.Version, requirement.Indirect)
+	}
+
+	// Output:
+	// github.com/pkg/errors v0.9.1 false
+	// github.com/stretchr/testify v1.7.0 false
+	// gopkg.in/yaml.v3 v3.0.1 true
+}
+
 func ExamplePrettyFormatJSON() {
 	type myStruct struct {
 		Foo string `json:"foo"`
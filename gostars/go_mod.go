@@ -0,0 +1,81 @@
+package gostars
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+)
+
+// ============================================================================
+//  Type: Requirement
+// ============================================================================
+
+// Requirement is a "require" entry of go.mod.
+type Requirement struct {
+	Path     string `json:"path"`     // Module path
+	Version  string `json:"version"`  // Required version
+	Indirect bool   `json:"indirect"` // True if marked as "// indirect"
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// ParseGoMod returns the "require" entries of the go.mod content in the order
+// of appearance. It is parsed by golang.org/x/mod/modfile as the go command
+// does. The directives other than "require" are ignored.
+func ParseGoMod(goMod []byte) ([]Requirement, error) {
+	file, err := modfile.ParseLax("go.mod", goMod, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid go.mod")
+	}
+
+	requirements := make([]Requirement, 0, len(file.Require))
+
+	for _, require := range file.Require {
+		requirements = append(requirements, Requirement{
+			Path:     require.Mod.Path,
+			Version:  require.Mod.Version,
+			Indirect: require.Indirect,
+		})
+	}
+
+	return requirements, nil
+}
+
+// ScoreGoMod scores the required modules in the go.mod content. It is a wrapper
+// of DefaultClient.ScoreGoMod.
+func ScoreGoMod(ctx context.Context, goMod []byte, withIndirect bool) ([]*Score, error) {
+	return DefaultClient.ScoreGoMod(ctx, goMod, withIndirect)
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// ScoreGoMod scores the required modules in the go.mod content with ScoreAll.
+// The modules marked as "// indirect" are skipped unless withIndirect is true.
+//
+// The repository of each module is resolved by ResolveImportPath as NewPkgInfo
+// does. Such as the go-import meta tags of the vanity import paths and the rules
+// of gopkg.in. pkg.go.dev is used only if they fail. The returned slice is in
+// the same order as in go.mod.
+func (c *Client) ScoreGoMod(ctx context.Context, goMod []byte, withIndirect bool) ([]*Score, error) {
+	requirements, err := ParseGoMod(goMod)
+	if err != nil {
+		return nil, err
+	}
+
+	namePkgs := make([]string, 0, len(requirements))
+
+	for _, requirement := range requirements {
+		if requirement.Indirect && !withIndirect {
+			continue
+		}
+
+		namePkgs = append(namePkgs, requirement.Path)
+	}
+
+	return c.ScoreAll(ctx, namePkgs), nil
+}
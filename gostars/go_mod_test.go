@@ -0,0 +1,117 @@
+package gostars_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  go.mod
+// ----------------------------------------------------------------------------
+
+func TestParseGoMod(t *testing.T) {
+	goMod := `
+module github.com/KEINOS/sample // comment
+
+require (
+	// comment line
+	"github.com/KEINOS/dev-go" v0.0.1 // indirect; some note
+
+	github.com/foo/bar v1.0.0 // not indirect
+)
+
+replace (
+	github.com/foo/bar => ../bar
+)
+
+require github.com/hoge/fuga v2.0.0+incompatible // indirect
+`
+
+	requirements, err := gostars.ParseGoMod([]byte(goMod))
+	require.NoError(t, err)
+
+	expect := []gostars.Requirement{
+		{Path: "github.com/KEINOS/dev-go", Version: "v0.0.1", Indirect: true},
+		{Path: "github.com/foo/bar", Version: "v1.0.0", Indirect: false},
+		{Path: "github.com/hoge/fuga", Version: "v2.0.0+incompatible", Indirect: true},
+	}
+
+	assert.Equal(t, expect, requirements)
+}
+
+func TestParseGoMod_compact(t *testing.T) {
+	// No space before the parenthesis and the comments other than "indirect"
+	goMod := `module github.com/KEINOS/sample
+
+go 1.24.0
+
+require(
+	github.com/KEINOS/dev-go v0.0.1 // pinned for the API of v0.0.1
+	github.com/foo/bar v1.0.0 //indirect
+	github.com/hoge/fuga v1.2.3 // indirectly used by the tests
+)
+`
+
+	requirements, err := gostars.ParseGoMod([]byte(goMod))
+	require.NoError(t, err)
+
+	expect := []gostars.Requirement{
+		{Path: "github.com/KEINOS/dev-go", Version: "v0.0.1", Indirect: false},
+		{Path: "github.com/foo/bar", Version: "v1.0.0", Indirect: true},
+		{Path: "github.com/hoge/fuga", Version: "v1.2.3", Indirect: false},
+	}
+
+	assert.Equal(t, expect, requirements)
+}
+
+func TestParseGoMod_malformed(t *testing.T) {
+	for _, test := range []struct {
+		goMod   string
+		contain string
+	}{
+		{"require github.com/foo/bar", "invalid go.mod: go.mod:1"},
+		{"module foo\nrequire (\n\tgithub.com/foo/bar v1.0.0 extra\n)", "invalid go.mod: go.mod:3"},
+		{"require \"github.com/foo/bar v1.0.0", "invalid go.mod: go.mod:1"},
+		{"require (\n\tgithub.com/foo/bar v1.0.0\n", "unterminated block"},
+	} {
+		requirements, err := gostars.ParseGoMod([]byte(test.goMod))
+
+		require.Error(t, err, "go.mod: %q", test.goMod)
+		assert.Contains(t, err.Error(), test.contain)
+		assert.Nil(t, requirements)
+	}
+}
+
+func TestClient_ScoreGoMod(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+	goMod := []byte(`
+require (
+	github.com/KEINOS/dev-go v0.0.1
+	github.com/KEINOS/undefined v0.0.1 // indirect
+)
+`)
+
+	// Without indirect
+	scores, err := client.ScoreGoMod(context.Background(), goMod, false)
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+
+	assert.Equal(t, "github.com/KEINOS/dev-go", scores[0].Name)
+	assert.Equal(t, 10, scores[0].Repo.Stars)
+
+	// With indirect
+	scores, err = client.ScoreGoMod(context.Background(), goMod, true)
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+
+	assert.Equal(t, "github.com/KEINOS/undefined", scores[1].Name)
+	assert.Error(t, scores[1].Err)
+
+	// Malformed go.mod
+	_, err = client.ScoreGoMod(context.Background(), []byte("require ("), true)
+	require.Error(t, err)
+}
@@ -2,12 +2,11 @@ package gostars
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"math"
-	"net/http"
-	"net/url"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -21,14 +20,29 @@ import (
 
 // CoolDown is a sleep function to avoid a large number of requests to each API.
 //
-// It is currently forced to 1 second.
+// It is currently forced to 1 second. Note that the requests of Client are
+// throttled per host by RateLimiter instead.
 func CoolDown() {
+	_ = CoolDownContext(context.Background())
+}
+
+// CoolDownContext is the same as CoolDown but it stops sleeping when ctx is
+// canceled or its deadline exceeds. In that case, it returns the error of ctx.
+func CoolDownContext(ctx context.Context) error {
 	// Ref:
 	//   Unauthenticated request: 60 req/hour ≅ 1 req/min
 	//   Authenticated request: 5,000 req/hour ≅ 1.4 req/sec
 	sleepSec := 1
 
-	time.Sleep(time.Duration(sleepSec*1000) * time.Millisecond)
+	timer := time.NewTimer(time.Duration(sleepSec*1000) * time.Millisecond)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // GetAttractionGravity returns the distance from the point 0 to the point of
@@ -52,36 +66,17 @@ func GetAttractionGravity(points ...int) int {
 
 // GetContentURL returns the content of a given URL.
 //
-// To avoid a large number of requests to the target server, it sleeps for about
-// one second.
+// It is a wrapper of DefaultClient.GetContentURL. To avoid a large number of
+// requests to the target server, the request waits for the RateLimiter of the
+// client as long as the limit of the host requires.
 func GetContentURL(urlTarget string) ([]byte, error) {
-	CoolDown()
-
-	urlParsed, err := url.Parse(urlTarget)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse URL before request")
-	}
-
-	response, err := http.Get(urlParsed.String())
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to fetch contents from the URL")
-	}
-
-	defer response.Body.Close()
-
-	if response.StatusCode != 200 {
-		return nil, errors.Errorf("failed to featch. returned status: %v",
-			response.StatusCode)
-	}
-
-	buf := new(bytes.Buffer)
-
-	// Copy data from the response to the buffer
-	if _, err = IOCopy(buf, response.Body); err != nil {
-		return nil, errors.Wrap(err, "failed to copy response data")
-	}
+	return DefaultClient.GetContentURL(urlTarget)
+}
 
-	return buf.Bytes(), nil
+// GetContentURLContext is the same as GetContentURL but with a context to cancel
+// the request.
+func GetContentURLContext(ctx context.Context, urlTarget string) ([]byte, error) {
+	return DefaultClient.GetContentURLContext(ctx, urlTarget)
 }
 
 // GetURLGitHub will return the URL of the GitHub repository if urlOrigin matches
@@ -1,7 +1,10 @@
 package gostars_test
 
 import (
+	"context"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -28,7 +31,27 @@ func TestCoolDown(t *testing.T) {
 	assert.GreaterOrEqual(t, e1, e2, "it should sleep more than equal to 1 second")
 }
 
+func TestCoolDownContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+
+	err := gostars.CoolDownContext(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start).Seconds(), float64(1), "it should not sleep if the context is canceled")
+}
+
 func TestGetContentURL(t *testing.T) {
+	server := newFakeServer(t)
+
+	t.Cleanup(useDefaultClient(newFakeClient(server)))
+
+	// Server which refuses the connection
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
 	// Backup and defer restore
 	oldIOCopy := gostars.IOCopy
 	defer func() {
@@ -44,10 +67,10 @@ func TestGetContentURL(t *testing.T) {
 		url     string
 		contain string
 	}{
-		{"https://github.com/KEINOS/" + string([]byte{0x7f}), "failed to parse URL before request"},
-		{"https://foo.bar.com/", "dial tcp: lookup foo.bar.com"},
-		{"https://github.com/KEINOS/unknownrepo/", "returned status: 404"},
-		{"https://github.com/KEINOS/dev-go/", "failed to copy response data"},
+		{server.URL + "/KEINOS/" + string([]byte{0x7f}), "failed to parse URL before request"},
+		{closed.URL + "/", "connection refused"},
+		{server.URL + "/KEINOS/unknownrepo/", "returned status: 404"},
+		{server.URL + "/content/hello.txt", "failed to copy response data"},
 	} {
 		_, err := gostars.GetContentURL(test.url)
 
@@ -100,69 +123,3 @@ func TestPrettyFormatJSON(t *testing.T) {
 	require.Error(t, err)
 	assert.Empty(t, output, "it should be empty on error")
 }
-
-// ----------------------------------------------------------------------------
-//  PkgInfo
-// ----------------------------------------------------------------------------
-
-func TestPkgInfo_bad_package_name(t *testing.T) {
-	namePkg := "github.com/KEINOS/undefined"
-
-	pkgInfo, err := gostars.NewPkgInfo(namePkg)
-
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to get 'imported by' information")
-	assert.Nil(t, pkgInfo)
-}
-
-func TestUpdateURLRepository_fail(t *testing.T) {
-	pkgInfo := &gostars.PkgInfo{
-		Name: "github.com/KEINOS/undefined",
-	}
-
-	err := pkgInfo.UpdateURLRepository()
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to get package information")
-}
-
-// ----------------------------------------------------------------------------
-//  RepoInfo
-// ----------------------------------------------------------------------------
-
-func TestRepoInfo_bad_url(t *testing.T) {
-	for _, test := range []struct {
-		url     string
-		contain string
-	}{
-		{"https://foo.bar.com/", "the URL must be under GitHub host"},
-		{"https://github.com/", "missing repo owner and/or repo name"},
-		{"https://github.com/KEINOS", "missing repo owner and/or repo name"},
-		{"https://github.com/KEINOS/" + string([]byte{0x7f}), "invalid control character in URL"},
-		{"https://github.com/KEINOS/undefined", "failed to update repository info"},
-	} {
-		repoInfo, err := gostars.NewRepoInfo(test.url)
-
-		require.Error(t, err, "'%v' should be an error", test.url)
-		assert.Nil(t, repoInfo, "it shuld be nil on error")
-		assert.Contains(t, err.Error(), test.contain)
-	}
-}
-
-func TestRepoInfo_Update_bad_credential(t *testing.T) {
-	oldGithubToken := gostars.GithubToken
-	defer func() {
-		gostars.GithubToken = oldGithubToken
-	}()
-
-	gostars.GithubToken = "<undefined>"
-
-	repoInfo := gostars.RepoInfo{
-		Name:  "dev-go",
-		Owner: "KEINOS",
-		URL:   new(gostars.URLInfo),
-	}
-
-	err := repoInfo.Update()
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "faild to get repository info")
-}
@@ -0,0 +1,469 @@
+package gostars
+
+import (
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Dimensions of the gravity. They are the keys of GravityModel.Weights.
+const (
+	DimStars      = "stars"       // Number of stars of the repository
+	DimForks      = "forks"       // Number of forks of the repository
+	DimFollowers  = "followers"   // Number of watchers of the repository
+	DimImportedBy = "imported_by" // Number of packages that import the package at the level of Score
+
+	// Dimensions of the repository activity. Except DimPushFreshness, they are
+	// available only if the activity was fetched. See Client.Activity.
+	DimCommits90d       = "commits_90d"       // Number of commits in the last 90 days
+	DimCodeFrequency    = "code_frequency"    // Average lines added and deleted per week in the last 90 days
+	DimReleases         = "releases"          // Number of releases
+	DimPushFreshness    = "push_freshness"    // 365 minus the days since the last push. 0 if older than a year
+	DimReleaseFreshness = "release_freshness" // 365 minus the days since the last release. 0 if older than a year
+
+	// Dimensions of the optional services. They are available only if fetched.
+	DimReportCard = "report_card" // Average of Go Report Card in percent. 0..100
+	DimCoverage   = "coverage"    // Code coverage rate in percent. 0..100
+)
+
+// Normalizations of the dimension values. See GravityModel.
+const (
+	NormNone   = "none"   // Raw values. The original formula of GetAttractionGravity
+	NormLog    = "log"    // Natural logarithm of (1 + value)
+	NormMinMax = "minmax" // Min-max scaling to 0..1 among the scored packages
+	NormZScore = "zscore" // Z-score among the scored packages mapped to 0..1 by the normal CDF
+)
+
+// scaleNormalized is the default Scale of the normalizations other than
+// NormNone. Since their values are small, the gravity would be truncated to a
+// few integers without scaling.
+const scaleNormalized = 100
+
+// dimensions is the list of functions that return the value of the dimension
+// from the score. The second return value is false if the value is not
+// available. Such as the dimensions of the optional services.
+var dimensions = map[string]func(score *Score) (float64, bool){
+	DimStars:      func(s *Score) (float64, bool) { return float64(s.Repo.Stars), true },
+	DimForks:      func(s *Score) (float64, bool) { return float64(s.Repo.Forks), true },
+	DimFollowers:  func(s *Score) (float64, bool) { return float64(s.Repo.Followers), true },
+	DimImportedBy: func(s *Score) (float64, bool) { return float64(s.Pkg.GetImportedBy(s.Level)), true },
+
+	DimCommits90d: func(s *Score) (float64, bool) {
+		if a := s.Repo.Activity; a != nil && !a.Pending {
+			return float64(a.Commits90d), true
+		}
+
+		return 0, false
+	},
+	DimCodeFrequency: func(s *Score) (float64, bool) {
+		if a := s.Repo.Activity; a != nil && !a.Pending {
+			return a.AverageCodeFrequency(), true
+		}
+
+		return 0, false
+	},
+	DimReleases: func(s *Score) (float64, bool) {
+		if a := s.Repo.Activity; a != nil {
+			return float64(a.Releases), true
+		}
+
+		return 0, false
+	},
+	DimPushFreshness: func(s *Score) (float64, bool) {
+		if s.Repo.LastPush.IsZero() {
+			return 0, false
+		}
+
+		return getFreshness(s.Repo.LastPush), true
+	},
+	DimReleaseFreshness: func(s *Score) (float64, bool) {
+		if a := s.Repo.Activity; a != nil {
+			// No release at all is as stale as the one older than a year
+			return getFreshness(a.LastRelease), true
+		}
+
+		return 0, false
+	},
+	DimReportCard: func(s *Score) (float64, bool) {
+		if s.ReportCard != nil {
+			return s.ReportCard.Average * 100, true
+		}
+
+		return 0, false
+	},
+	DimCoverage: func(s *Score) (float64, bool) {
+		if s.Coverage != nil {
+			return s.Coverage.Coverage, true
+		}
+
+		return 0, false
+	},
+}
+
+// activityDimensions are the dimensions that require RepoInfo.UpdateActivity.
+var activityDimensions = []string{DimCommits90d, DimCodeFrequency, DimReleases, DimReleaseFreshness}
+
+// ============================================================================
+//  Type: GravityModel
+// ============================================================================
+
+// GravityModel defines how to calculate the attraction gravity from the
+// dimensions of a package. Such as the number of stars and imported by.
+//
+// The gravity is the Euclidean norm of the weighted and normalized values of
+// the dimensions multiplied by Scale:
+//
+//	gravity = Scale * sqrt( sum( (weight * normalize(value))^2 ) )
+//
+// The dimensions with zero weight are ignored. NormMinMax and NormZScore are
+// relative to the packages scored together. With NormMinMax, the lowest value
+// among them is 0 and the highest is 1. Thus the package lowest in all the
+// dimensions has zero gravity. With a single package, or if all the values of
+// a dimension are equal, the values are 0.5, the center of the range.
+//
+// DefaultGravityModel is the same formula as GetAttractionGravity.
+type GravityModel struct {
+	Weights       map[string]float64 `json:"weights" yaml:"weights"`             // Weight per dimension. See Dim* constants
+	Normalization string             `json:"normalization" yaml:"normalization"` // One of Norm* constants
+	Scale         float64            `json:"scale" yaml:"scale"`                 // Multiplier of the gravity. 0 for the default of the normalization
+}
+
+// GravityBreakdown is the detail of how the gravity of a package was
+// calculated by the GravityModel.
+type GravityBreakdown struct {
+	Gravity       int                  `json:"gravity"`       // Final gravity
+	Normalization string               `json:"normalization"` // Normalization of the model
+	Scale         float64              `json:"scale"`         // Multiplier of the gravity
+	Dimensions    []DimensionBreakdown `json:"dimensions"`    // Weighted dimensions in the order of name
+}
+
+// DimensionBreakdown is the contribution of a dimension to the gravity.
+//
+// Since the gravity is the Euclidean norm, the share is the ratio of the
+// squared weighted value to the sum of them. The shares add up to 1.
+type DimensionBreakdown struct {
+	Name       string  `json:"name"`       // Name of the dimension. See Dim* constants
+	Value      float64 `json:"value"`      // Raw value. Such as the number of stars
+	Weight     float64 `json:"weight"`     // Weight of the dimension in the model
+	Normalized float64 `json:"normalized"` // Value after the normalization
+	Share      float64 `json:"share"`      // Share of the final gravity. 0..1
+	Available  bool    `json:"available"`  // False if the value was not available and ignored
+}
+
+// dimStats is the statistics of a dimension among the scored packages.
+type dimStats struct {
+	Min    float64
+	Max    float64
+	Mean   float64
+	StdDev float64
+}
+
+// ============================================================================
+//  Constructor
+// ============================================================================
+
+// DefaultGravityModel returns the model of the original formula. Which is the
+// Euclidean norm of stars, forks, followers and imported by without weighting.
+func DefaultGravityModel() *GravityModel {
+	return &GravityModel{
+		Weights: map[string]float64{
+			DimStars:      1,
+			DimForks:      1,
+			DimFollowers:  1,
+			DimImportedBy: 1,
+		},
+		Normalization: NormNone,
+	}
+}
+
+// LoadGravityModel reads the model from the YAML or JSON file. See
+// ParseGravityModel for the format.
+func LoadGravityModel(path string) (*GravityModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read gravity model")
+	}
+
+	return ParseGravityModel(data)
+}
+
+// ParseGravityModel parses the model in YAML or JSON. The fields not set are
+// the values of DefaultGravityModel. Set the weight to 0 to ignore the
+// dimension. Such as:
+//
+//	normalization: log
+//	weights:
+//	  imported_by: 0.5
+//	  followers: 0
+func ParseGravityModel(data []byte) (*GravityModel, error) {
+	model := DefaultGravityModel()
+
+	// YAML is a superset of JSON
+	if err := yaml.Unmarshal(data, model); err != nil {
+		return nil, errors.Wrap(err, "malformed gravity model")
+	}
+
+	if err := model.Validate(); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// ListDimensions returns the sorted names of the available dimensions.
+func ListDimensions() []string {
+	names := make([]string, 0, len(dimensions))
+
+	for name := range dimensions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ListNormalizations returns the names of the available normalizations.
+func ListNormalizations() []string {
+	return []string{NormNone, NormLog, NormMinMax, NormZScore}
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// Apply recalculates the gravity and its breakdown of the scores with the
+// model. The scores failed are skipped.
+//
+// For NormMinMax and NormZScore, the scores are normalized among each other.
+// The breakdown is recalculated together so that it always explains the
+// gravity of the batch.
+func (m *GravityModel) Apply(scores []*Score) {
+	stats := m.getStats(scores)
+
+	for _, score := range scores {
+		if isScored(score) {
+			score.Breakdown = m.calculate(score, stats)
+			score.Gravity = score.Breakdown.Gravity
+		}
+	}
+}
+
+// Explain returns the breakdown of the gravity of the single score with the
+// model. It returns nil if the score failed.
+func (m *GravityModel) Explain(score *Score) *GravityBreakdown {
+	if !isScored(score) {
+		return nil
+	}
+
+	return m.calculate(score, m.getStats([]*Score{score}))
+}
+
+// Gravity returns the gravity of the single score with the model. It returns
+// 0 if the score failed.
+func (m *GravityModel) Gravity(score *Score) int {
+	if breakdown := m.Explain(score); breakdown != nil {
+		return breakdown.Gravity
+	}
+
+	return 0
+}
+
+// Validate returns an error if the model has an unknown dimension or
+// normalization, or a negative weight or scale.
+func (m *GravityModel) Validate() error {
+	for name, weight := range m.Weights {
+		if _, ok := dimensions[name]; !ok {
+			return errors.Errorf("unknown dimension of gravity: %q (available: %s)",
+				name, strings.Join(ListDimensions(), ", "))
+		}
+
+		if weight < 0 || math.IsNaN(weight) {
+			return errors.Errorf("weight of %s must be 0 or greater: %v", name, weight)
+		}
+	}
+
+	switch m.Normalization {
+	case "", NormNone, NormLog, NormMinMax, NormZScore:
+	default:
+		return errors.Errorf("unknown normalization: %q (available: %s)",
+			m.Normalization, strings.Join(ListNormalizations(), ", "))
+	}
+
+	if m.Scale < 0 {
+		return errors.Errorf("scale must be 0 or greater: %v", m.Scale)
+	}
+
+	return nil
+}
+
+// calculate returns the breakdown of the gravity of the score with the
+// statistics of the dimensions.
+func (m *GravityModel) calculate(score *Score, stats map[string]dimStats) *GravityBreakdown {
+	breakdown := &GravityBreakdown{
+		Normalization: m.Normalization,
+		Scale:         m.getScale(),
+		Dimensions:    []DimensionBreakdown{},
+	}
+
+	if breakdown.Normalization == "" {
+		breakdown.Normalization = NormNone
+	}
+
+	sumSquares := 0.0
+
+	// Sum in a fixed order to get the same result every time
+	for _, name := range m.getNames() {
+		dim := DimensionBreakdown{Name: name, Weight: m.Weights[name]}
+
+		if value, ok := getDimension(score, name); ok {
+			dim.Value = value
+			dim.Normalized = m.normalize(value, stats[name])
+			dim.Available = true
+
+			weighted := dim.Weight * dim.Normalized
+			dim.Share = weighted * weighted // Divided by the sum later
+			sumSquares += dim.Share
+		}
+
+		breakdown.Dimensions = append(breakdown.Dimensions, dim)
+	}
+
+	for i := range breakdown.Dimensions {
+		if sumSquares > 0 {
+			breakdown.Dimensions[i].Share /= sumSquares
+		}
+	}
+
+	breakdown.Gravity = int(breakdown.Scale * math.Sqrt(sumSquares))
+
+	return breakdown
+}
+
+// getNames returns the sorted names of the dimensions with non-zero weight.
+func (m *GravityModel) getNames() []string {
+	names := []string{}
+
+	for name, weight := range m.Weights {
+		if weight != 0 {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// needs returns true if any of the dimensions is weighted.
+func (m *GravityModel) needs(names ...string) bool {
+	for _, name := range names {
+		if m.Weights[name] > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getScale returns the Scale or its default of the normalization.
+func (m *GravityModel) getScale() float64 {
+	switch {
+	case m.Scale > 0:
+		return m.Scale
+	case m.Normalization == "" || m.Normalization == NormNone:
+		return 1
+	default:
+		return scaleNormalized
+	}
+}
+
+// getStats returns the statistics of the weighted dimensions among the scores.
+func (m *GravityModel) getStats(scores []*Score) map[string]dimStats {
+	stats := map[string]dimStats{}
+
+	for _, name := range m.getNames() {
+		values := []float64{}
+
+		for _, score := range scores {
+			if value, ok := getDimension(score, name); ok && isScored(score) {
+				values = append(values, value)
+			}
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		stat := dimStats{Min: values[0], Max: values[0]}
+		sum := 0.0
+
+		for _, value := range values {
+			stat.Min = math.Min(stat.Min, value)
+			stat.Max = math.Max(stat.Max, value)
+			sum += value
+		}
+
+		stat.Mean = sum / float64(len(values))
+
+		sumDiff := 0.0
+
+		for _, value := range values {
+			sumDiff += (value - stat.Mean) * (value - stat.Mean)
+		}
+
+		stat.StdDev = math.Sqrt(sumDiff / float64(len(values)))
+		stats[name] = stat
+	}
+
+	return stats
+}
+
+// normalize returns the normalized value with the statistics of the dimension.
+func (m *GravityModel) normalize(value float64, stat dimStats) float64 {
+	switch m.Normalization {
+	case NormLog:
+		return math.Log1p(math.Max(value, 0))
+	case NormMinMax:
+		if stat.Max == stat.Min {
+			return 0.5
+		}
+
+		return (value - stat.Min) / (stat.Max - stat.Min)
+	case NormZScore:
+		if stat.StdDev == 0 {
+			return 0.5
+		}
+
+		// Map to 0..1 so that the values below the mean do not add the gravity
+		// as much as the ones above.
+		zScore := (value - stat.Mean) / stat.StdDev
+
+		return 0.5 * (1 + math.Erf(zScore/math.Sqrt2))
+	default:
+		return value
+	}
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// getDimension returns the value of the dimension of the score.
+func getDimension(score *Score, name string) (float64, bool) {
+	getValue, ok := dimensions[name]
+	if !ok || !isScored(score) {
+		return 0, false
+	}
+
+	return getValue(score)
+}
+
+// isScored returns true if the score has the package and repository info.
+func isScored(score *Score) bool {
+	return score != nil && score.Err == nil && score.Pkg != nil && score.Repo != nil
+}
@@ -0,0 +1,224 @@
+package gostars_test
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  GravityModel
+// ----------------------------------------------------------------------------
+
+// newScore returns a succeeded Score with the given dimensions.
+func newScore(stars, forks, followers, importedBy int) *gostars.Score {
+	return &gostars.Score{
+		Pkg:  &gostars.PkgInfo{ImportedBy: importedBy},
+		Repo: &gostars.RepoInfo{Stars: stars, Forks: forks, Followers: followers},
+	}
+}
+
+func TestDefaultGravityModel(t *testing.T) {
+	model := gostars.DefaultGravityModel()
+
+	for _, dims := range [][]int{
+		{0, 0, 0, 0},
+		{10, 3, 2, 2},
+		{10426, 850, 300, 7000},
+	} {
+		score := newScore(dims[0], dims[1], dims[2], dims[3])
+
+		assert.Equal(t, gostars.GetAttractionGravity(dims...), model.Gravity(score),
+			"default model should be the same as the original formula")
+	}
+
+	assert.Zero(t, model.Gravity(&gostars.Score{Err: errors.New("dummy")}))
+}
+
+func TestGravityModel_weights_and_log(t *testing.T) {
+	score := newScore(3, 100, 100, 4)
+
+	model := gostars.DefaultGravityModel()
+	model.Weights[gostars.DimForks] = 0
+	model.Weights[gostars.DimFollowers] = 0
+
+	assert.Equal(t, 5, model.Gravity(score), "zero weight should ignore the dimension")
+
+	model.Weights[gostars.DimStars] = 2
+
+	assert.Equal(t, 7, model.Gravity(score), "sqrt((2*3)^2 + 4^2) = 7.2")
+
+	// Log scaling. 100 * sqrt((2*ln(4))^2 + ln(5)^2) = 320.6
+	model.Normalization = gostars.NormLog
+
+	assert.Equal(t, 320, model.Gravity(score))
+
+	model.Scale = 1
+
+	assert.Equal(t, 3, model.Gravity(score))
+}
+
+func TestGravityModel_Apply(t *testing.T) {
+	scores := []*gostars.Score{
+		newScore(0, 0, 0, 0),
+		newScore(50, 0, 0, 0),
+		newScore(100, 0, 0, 0),
+		{Name: "failed", Err: errors.New("dummy"), Gravity: -1},
+	}
+
+	model := &gostars.GravityModel{
+		Weights:       map[string]float64{gostars.DimStars: 1},
+		Normalization: gostars.NormMinMax,
+	}
+
+	model.Apply(scores)
+
+	assert.Equal(t, 0, scores[0].Gravity)
+	assert.Equal(t, 50, scores[1].Gravity)
+	assert.Equal(t, 100, scores[2].Gravity)
+	assert.Equal(t, -1, scores[3].Gravity, "failed score should be untouched")
+
+	// Z-score mapped by the normal CDF. The mean is the center
+	model.Normalization = gostars.NormZScore
+
+	model.Apply(scores)
+
+	assert.Less(t, scores[0].Gravity, scores[1].Gravity)
+	assert.Equal(t, 50, scores[1].Gravity)
+	assert.Less(t, scores[1].Gravity, scores[2].Gravity)
+
+	// Single package is the center of the range
+	assert.Equal(t, 50, model.Gravity(scores[2]))
+}
+
+func TestGravityModel_Apply_breakdown(t *testing.T) {
+	model := &gostars.GravityModel{
+		Weights:       map[string]float64{gostars.DimStars: 1, gostars.DimForks: 2},
+		Normalization: gostars.NormMinMax,
+	}
+
+	// Explained alone first. Such as by ScorePackage
+	scores := []*gostars.Score{newScore(10, 1, 0, 0), newScore(20, 3, 0, 0), newScore(40, 2, 0, 0)}
+
+	for _, score := range scores {
+		model.Apply([]*gostars.Score{score})
+		require.Equal(t, 111, score.Gravity, "single package should be the center: 100*sqrt(0.5^2 + 1^2)")
+	}
+
+	model.Apply(scores)
+
+	for _, score := range scores {
+		require.NotNil(t, score.Breakdown)
+
+		sumSquares := 0.0
+
+		for _, dim := range score.Breakdown.Dimensions {
+			sumSquares += (dim.Weight * dim.Normalized) * (dim.Weight * dim.Normalized)
+		}
+
+		// The breakdown should explain the gravity among the batch
+		assert.Equal(t, score.Gravity, score.Breakdown.Gravity)
+		assert.Equal(t, score.Gravity, int(score.Breakdown.Scale*math.Sqrt(sumSquares)))
+	}
+
+	// The lowest in all the dimensions is 0
+	assert.Zero(t, scores[0].Gravity)
+	assert.Equal(t, []float64{0, 0}, []float64{
+		scores[0].Breakdown.Dimensions[0].Normalized, scores[0].Breakdown.Dimensions[1].Normalized,
+	})
+
+	// forks = 2 of 1..3 and stars = 40 of 10..40
+	assert.Equal(t, 0.5, scores[2].Breakdown.Dimensions[0].Normalized)
+	assert.Equal(t, 1.0, scores[2].Breakdown.Dimensions[1].Normalized)
+
+	// All equal is the center of the range in both normalizations
+	equals := []*gostars.Score{newScore(5, 5, 0, 0), newScore(5, 5, 0, 0)}
+
+	for _, normalization := range []string{gostars.NormMinMax, gostars.NormZScore} {
+		model.Normalization = normalization
+
+		model.Apply(equals)
+
+		for _, score := range equals {
+			assert.Equal(t, 111, score.Gravity, normalization)
+			assert.Equal(t, 0.5, score.Breakdown.Dimensions[0].Normalized, normalization)
+			assert.Equal(t, 0.5, score.Breakdown.Dimensions[1].Normalized, normalization)
+		}
+	}
+}
+
+func TestGravityModel_Explain(t *testing.T) {
+	model := gostars.DefaultGravityModel()
+	model.Weights[gostars.DimFollowers] = 0
+
+	breakdown := model.Explain(newScore(3, 0, 100, 4))
+	require.NotNil(t, breakdown)
+
+	assert.Equal(t, 5, breakdown.Gravity)
+	assert.Equal(t, gostars.NormNone, breakdown.Normalization)
+	assert.Equal(t, 1.0, breakdown.Scale)
+
+	// Sorted by name and without the zero weight
+	assert.Equal(t, []gostars.DimensionBreakdown{
+		{Name: gostars.DimForks, Value: 0, Weight: 1, Normalized: 0, Share: 0, Available: true},
+		{Name: gostars.DimImportedBy, Value: 4, Weight: 1, Normalized: 4, Share: 0.64, Available: true},
+		{Name: gostars.DimStars, Value: 3, Weight: 1, Normalized: 3, Share: 0.36, Available: true},
+	}, breakdown.Dimensions)
+
+	assert.Nil(t, model.Explain(&gostars.Score{Err: errors.New("dummy")}))
+
+	// Apply sets the breakdown
+	scores := []*gostars.Score{newScore(3, 0, 0, 4)}
+
+	model.Apply(scores)
+
+	require.NotNil(t, scores[0].Breakdown)
+	assert.Equal(t, scores[0].Gravity, scores[0].Breakdown.Gravity)
+}
+
+func TestParseGravityModel(t *testing.T) {
+	// YAML
+	model, err := gostars.ParseGravityModel([]byte("normalization: log\nweights:\n  imported_by: 0.5\n  followers: 0\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, gostars.NormLog, model.Normalization)
+	assert.Equal(t, map[string]float64{
+		gostars.DimStars:      1,
+		gostars.DimForks:      1,
+		gostars.DimFollowers:  0,
+		gostars.DimImportedBy: 0.5,
+	}, model.Weights, "unset weights should be the default")
+
+	// JSON
+	model, err = gostars.ParseGravityModel([]byte(`{"scale": 10, "weights": {"stars": 2}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, gostars.NormNone, model.Normalization)
+	assert.Equal(t, 10.0, model.Scale)
+	assert.Equal(t, 2.0, model.Weights[gostars.DimStars])
+
+	// Errors
+	for _, test := range []struct {
+		input   string
+		contain string
+	}{
+		{"weights: [1, 2]", "malformed gravity model"},
+		{"weights: {unknown: 1}", "unknown dimension of gravity"},
+		{"weights: {stars: -1}", "weight of stars must be 0 or greater"},
+		{"normalization: cubic", "unknown normalization"},
+		{"scale: -1", "scale must be 0 or greater"},
+	} {
+		_, err := gostars.ParseGravityModel([]byte(test.input))
+
+		require.Error(t, err, "input: %s", test.input)
+		assert.Contains(t, err.Error(), test.contain)
+	}
+
+	_, err = gostars.LoadGravityModel(filepath.Join(t.TempDir(), "unknown.yaml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package gostars_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+)
+
+// ----------------------------------------------------------------------------
+//  Helper Functions
+// ----------------------------------------------------------------------------
+
+// ago returns the time of the hours ago in RFC3339 to mock the responses.
+func ago(hours int) string {
+	return time.Now().Add(-time.Duration(hours) * time.Hour).UTC().Format(time.RFC3339)
+}
+
+// newFakeServer returns a mock server of newFakeHandler which is closed at the
+// end of the test. Use newFakeClient to get a Client that points to the server.
+func newFakeServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(newFakeHandler())
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newFakeHandler returns a mock of pkg.go.dev, GitHub API and a static content.
+// Which serves the "github.com/KEINOS/dev-go" package only.
+//
+// The mocks of the other services, such as the activity and the health of the
+// repository, are added by the add*Handlers in the test file of each feature.
+func newFakeHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	// Mock of pkg.go.dev
+	mux.HandleFunc("/pkg/github.com/KEINOS/dev-go", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tab") == "importedby" {
+			fmt.Fprint(w, `<html><body>
+<div class="u-breakWord">github.com/foo/bar</div>
+<div class="u-breakWord">github.com/hoge/fuga</div>
+</body></html>`)
+
+			return
+		}
+
+		fmt.Fprint(w, `<html><body>
+<div class="UnitMeta-repo"><a href="https://github.com/KEINOS/dev-go">github.com/KEINOS/dev-go</a></div>
+</body></html>`)
+	})
+
+	// Mock of GitHub API
+	mux.HandleFunc("/api/repos/KEINOS/dev-go", func(w http.ResponseWriter, r *http.Request) {
+		const etag = `"dev-go"`
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, `{
+"name": "dev-go",
+"description": "Dockerfile for Go development",
+"stargazers_count": 10,
+"forks_count": 3,
+"subscribers_count": 2,
+"pushed_at": "2022-01-02T03:04:05Z"
+}`)
+	})
+
+	// Mocks of the features. See the test file of each feature
+	addActivityHandlers(mux)
+	addHealthHandlers(mux)
+	addProviderHandlers(mux)
+	addReportCardHandlers(mux)
+	addCoverageHandlers(mux)
+	addModuleHandlers(mux)
+
+	// Mock of static content
+	mux.HandleFunc("/content/hello.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Hello, world!")
+	})
+
+	return mux
+}
+
+// newFakeClient returns a Client which requests to the given mock server.
+func newFakeClient(server *httptest.Server) *gostars.Client {
+	client := gostars.NewClient()
+
+	client.HTTPClient = &http.Client{Transport: &rerouteTransport{
+		base: server.Client().Transport,
+		host: server.Listener.Addr().String(),
+	}}
+	client.GitHubBaseURL = server.URL + "/api/"
+	client.PkgGoDevBaseURL = server.URL + "/pkg"
+	client.ReportCardBaseURL = server.URL + "/reportcard"
+	client.CodecovBaseURL = server.URL + "/codecov"
+	client.CoverallsBaseURL = server.URL + "/coveralls"
+	client.RawContentBaseURL = server.URL + "/raw"
+	client.GoProxyBaseURL = server.URL + "/proxy"
+	client.Providers["gitlab.com"] = &gostars.GitLabProvider{BaseURL: server.URL + "/gitlab"}
+	client.Providers["bitbucket.org"] = &gostars.BitbucketProvider{BaseURL: server.URL + "/bitbucket"}
+	client.Providers["codeberg.org"] = &gostars.GiteaProvider{BaseURL: server.URL + "/gitea"}
+	client.RateLimiter = nil // no need to throttle the mock server
+
+	return client
+}
+
+// useDefaultClient replaces DefaultClient with the client until the returned
+// function is called. Such as to test the package-level wrappers.
+func useDefaultClient(client *gostars.Client) func() {
+	oldDefaultClient := gostars.DefaultClient
+
+	gostars.DefaultClient = client
+
+	return func() {
+		gostars.DefaultClient = oldDefaultClient
+	}
+}
+
+// rerouteTransport sends all the requests to the real hosts to the mock server.
+// Such as the go-import meta tags of "https://codeberg.org/<path>?go-get=1", to
+// not access the real hosts in the tests. The local hosts are left as is.
+type rerouteTransport struct {
+	base http.RoundTripper
+	host string
+}
+
+func (t *rerouteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if host := req.URL.Hostname(); host != "127.0.0.1" && host != "localhost" {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = "http"
+		req.URL.Host = t.host
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// countRequests wraps the handler of the server to count the requests per path.
+func countRequests(t *testing.T, server *httptest.Server) map[string]int {
+	t.Helper()
+
+	var mutex sync.Mutex
+
+	counts := map[string]int{}
+	handler := server.Config.Handler
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		counts[r.URL.Path]++
+		mutex.Unlock()
+
+		handler.ServeHTTP(w, r)
+	})
+
+	return counts
+}
+
+// sumRequests returns the number of the requests counted by countRequests
+// whose path starts with prefix.
+func sumRequests(counts map[string]int, prefix string) int {
+	sum := 0
+
+	for path, count := range counts {
+		if strings.HasPrefix(path, prefix) {
+			sum += count
+		}
+	}
+
+	return sum
+}
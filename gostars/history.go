@@ -0,0 +1,222 @@
+package gostars
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EnvHistoryFile is the name of the environment variable to change the path of
+// the history file. See DefaultHistoryPath.
+const EnvHistoryFile = "GOSTARS_HISTORY_FILE"
+
+// daysPerMonth is the number of days in a month to calculate the growth rate.
+const daysPerMonth = 30
+
+// ============================================================================
+//  Type: Snapshot
+// ============================================================================
+
+// Snapshot is a record of the Score of a package at a point in time.
+//
+// To be comparable between the runs, the numbers do not depend on the options
+// of the run. Such as the GravityModel and the Level of the client. ImportedBy is
+// the one of the package and Gravity is the one of GetAttractionGravity, which
+// is the DefaultGravityModel without normalization.
+type Snapshot struct {
+	Time       time.Time `json:"time"`        // Time when the package was scored
+	Name       string    `json:"name"`        // Name of the package
+	Repository string    `json:"repository"`  // Repository URL of the package
+	Stars      int       `json:"stars"`       // Number of stars of the repository
+	Forks      int       `json:"forks"`       // Number of forks of the repository
+	Followers  int       `json:"followers"`   // Number of watchers of the repository
+	ImportedBy int       `json:"imported_by"` // Number of packages that import the package
+	Gravity    int       `json:"gravity"`     // Attraction gravity of the package by the default model
+}
+
+// NewSnapshot returns the Snapshot of the score at the given time. It returns
+// false if the score failed or both the package and the repository info came
+// from the cache. Since it would be the same point as the previous run.
+func NewSnapshot(score *Score, at time.Time) (Snapshot, bool) {
+	if score == nil || score.Err != nil || score.Pkg == nil || score.Repo == nil {
+		return Snapshot{}, false
+	}
+
+	if score.Pkg.cached && score.Repo.cached {
+		return Snapshot{}, false
+	}
+
+	return Snapshot{
+		Time:       at,
+		Name:       score.Name,
+		Repository: score.Pkg.Repository,
+		Stars:      score.Repo.Stars,
+		Forks:      score.Repo.Forks,
+		Followers:  score.Repo.Followers,
+		ImportedBy: score.Pkg.ImportedBy,
+		Gravity: GetAttractionGravity(
+			score.Repo.Stars, score.Repo.Forks, score.Repo.Followers, score.Pkg.ImportedBy,
+		),
+	}, true
+}
+
+// ============================================================================
+//  Type: History
+// ============================================================================
+
+// History is a store of the snapshots in a JSON-lines file. One snapshot per
+// line is appended so the file can be read by other tools as well. Such as jq.
+type History struct {
+	Path string // Path of the JSON-lines file
+}
+
+// NewHistory returns a new History that stores the snapshots in path.
+func NewHistory(path string) *History {
+	return &History{Path: path}
+}
+
+// DefaultHistoryPath returns the default path of the history file. Which is
+// the value of GOSTARS_HISTORY_FILE environment variable if set, otherwise
+// "gostars/history.jsonl" under the user config directory. Such as
+// ~/.config/gostars/history.jsonl on Linux.
+//
+// Unlike Cache, the history is not under the cache directory since it can not
+// be fetched again.
+func DefaultHistoryPath() (string, error) {
+	if path := os.Getenv(EnvHistoryFile); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get user config directory")
+	}
+
+	return filepath.Join(dir, "gostars", "history.jsonl"), nil
+}
+
+// Append appends the snapshots to the history file. The file and its directory
+// are created if not exist.
+func (h *History) Append(snapshots ...Snapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+
+	for _, snapshot := range snapshots {
+		if err := encoder.Encode(snapshot); err != nil {
+			return errors.Wrap(err, "failed to encode snapshot")
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create history directory")
+	}
+
+	file, err := os.OpenFile(h.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open history file")
+	}
+
+	// Write at once to not interleave with other processes
+	_, err = file.Write(buf.Bytes())
+
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+
+	return errors.Wrap(err, "failed to write history file")
+}
+
+// Load returns the snapshots of the package in chronological order. If the
+// history file does not exist, it returns an empty slice.
+//
+// The malformed lines are skipped to not lose the whole history by a broken
+// line. Such as the one written by an interrupted run.
+func (h *History) Load(namePkg string) ([]Snapshot, error) {
+	snapshots := []Snapshot{}
+
+	data, err := os.ReadFile(h.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snapshots, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to read history file")
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		var snapshot Snapshot
+
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			continue
+		}
+
+		if snapshot.Name == namePkg {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+
+	return snapshots, errors.Wrap(scanner.Err(), "failed to read history file")
+}
+
+// ============================================================================
+//  Type: Trend
+// ============================================================================
+
+// Trend is the change of the gravity between the first and the last snapshots.
+type Trend struct {
+	First         Snapshot `json:"first"`           // Oldest snapshot
+	Last          Snapshot `json:"last"`            // Latest snapshot
+	Delta         int      `json:"delta"`           // Change of the gravity
+	DeltaPerMonth float64  `json:"delta_per_month"` // Average change of the gravity per 30 days
+	GrowthRate    float64  `json:"growth_rate"`     // Compound growth rate of the gravity per 30 days in percent
+}
+
+// NewTrend returns the Trend of the snapshots in chronological order. It
+// returns an error if there is no snapshot.
+//
+// If the period is less than a day or the first gravity is 0, the rates are 0.
+func NewTrend(snapshots []Snapshot) (*Trend, error) {
+	if len(snapshots) == 0 {
+		return nil, errors.New("no snapshot to analyze")
+	}
+
+	first := snapshots[0]
+	last := snapshots[len(snapshots)-1]
+
+	trend := &Trend{
+		First: first,
+		Last:  last,
+		Delta: last.Gravity - first.Gravity,
+	}
+
+	months := last.Time.Sub(first.Time).Hours() / 24 / daysPerMonth
+	if months < 1.0/daysPerMonth {
+		return trend, nil
+	}
+
+	trend.DeltaPerMonth = float64(trend.Delta) / months
+
+	if first.Gravity > 0 {
+		ratio := float64(last.Gravity) / float64(first.Gravity)
+		trend.GrowthRate = (math.Pow(ratio, 1/months) - 1) * 100
+	}
+
+	return trend, nil
+}
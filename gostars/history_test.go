@@ -0,0 +1,130 @@
+package gostars_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  History
+// ----------------------------------------------------------------------------
+
+func TestHistory(t *testing.T) {
+	pathHistory := filepath.Join(t.TempDir(), "sub", "history.jsonl")
+	history := gostars.NewHistory(pathHistory)
+
+	// Not recorded yet
+	snapshots, err := history.Load("github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	score := &gostars.Score{
+		Name:    "github.com/KEINOS/dev-go",
+		Pkg:     &gostars.PkgInfo{Repository: "https://github.com/KEINOS/dev-go", ImportedBy: 2},
+		Repo:    &gostars.RepoInfo{Stars: 10, Forks: 3, Followers: 1},
+		Gravity: 100, // such as by a custom model
+		Level:   gostars.LevelRepo,
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	newer, ok := gostars.NewSnapshot(score, now)
+	require.True(t, ok)
+
+	older, ok := gostars.NewSnapshot(score, now.Add(-time.Hour))
+	require.True(t, ok)
+
+	other := older
+	other.Name = "github.com/foo/bar"
+
+	_, ok = gostars.NewSnapshot(&gostars.Score{Name: "failed", Err: errors.New("dummy")}, now)
+	require.False(t, ok, "failed score should not be a snapshot")
+
+	require.NoError(t, history.Append(newer, other))
+	require.NoError(t, history.Append(older))
+	require.NoError(t, history.Append())
+
+	// Broken line such as by an interrupted run
+	file, err := os.OpenFile(pathHistory, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+
+	_, err = file.WriteString("{\"name\": \"github.com/KEINOS/d")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	snapshots, err = history.Load("github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+
+	assert.Equal(t, older.Time, snapshots[0].Time.UTC(), "it should be in chronological order")
+	assert.Equal(t, newer.Time, snapshots[1].Time.UTC(), "it should be in chronological order")
+	assert.Equal(t, 2, snapshots[1].ImportedBy)
+	assert.Equal(t, gostars.GetAttractionGravity(10, 3, 1, 2), snapshots[1].Gravity,
+		"it should be the gravity of the default model to be comparable")
+}
+
+func TestNewSnapshot_cached(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+	client.Cache = gostars.NewCache(t.TempDir(), time.Hour)
+
+	score, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	_, ok := gostars.NewSnapshot(score, time.Now())
+	require.True(t, ok)
+
+	score, err = client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	_, ok = gostars.NewSnapshot(score, time.Now())
+	require.False(t, ok, "the score from the cache should not be a new snapshot")
+}
+
+func TestHistory_Append_fail(t *testing.T) {
+	pathFile := filepath.Join(t.TempDir(), "file")
+
+	require.NoError(t, os.WriteFile(pathFile, nil, 0o600))
+
+	// Parent is not a directory
+	history := gostars.NewHistory(filepath.Join(pathFile, "history.jsonl"))
+
+	require.Error(t, history.Append(gostars.Snapshot{Name: "foo"}))
+}
+
+func TestNewTrend(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := gostars.NewTrend(nil)
+	require.Error(t, err)
+
+	// Doubled in 60 days
+	trend, err := gostars.NewTrend([]gostars.Snapshot{
+		{Time: start, Gravity: 100},
+		{Time: start.AddDate(0, 0, 30), Gravity: 90},
+		{Time: start.AddDate(0, 0, 60), Gravity: 200},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 100, trend.Delta)
+	assert.InDelta(t, 50.0, trend.DeltaPerMonth, 0.001)
+	assert.InDelta(t, 41.421, trend.GrowthRate, 0.001)
+
+	// Too short period
+	trend, err = gostars.NewTrend([]gostars.Snapshot{
+		{Time: start, Gravity: 100},
+		{Time: start.Add(time.Hour), Gravity: 200},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 100, trend.Delta)
+	assert.Zero(t, trend.DeltaPerMonth)
+	assert.Zero(t, trend.GrowthRate)
+}
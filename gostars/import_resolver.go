@@ -0,0 +1,204 @@
+package gostars
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
+)
+
+// Sources of the repository URL. See ImportInfo.
+const (
+	SourceHost     = "host"       // Import path under the host of a RepoProvider. Such as github.com
+	SourceGopkgIn  = "gopkg.in"   // Version rules of gopkg.in
+	SourceGoImport = "go-import"  // go-import or go-source meta tags of the vanity import path
+	SourcePkgGoDev = "pkg.go.dev" // Repository field of pkg.go.dev
+)
+
+// reGopkgIn matches the import path of gopkg.in. Such as "gopkg.in/yaml.v3" and
+// "gopkg.in/user/pkg.v1-unstable/sub".
+var reGopkgIn = regexp.MustCompile(
+	`^gopkg\.in/(?:([a-zA-Z0-9][-a-zA-Z0-9]*)/)?([a-zA-Z][-a-zA-Z0-9_]*)\.v(?:0|[1-9][0-9]*)(?:-unstable)?(?:/.*)?$`,
+)
+
+// ============================================================================
+//  Type: ImportInfo
+// ============================================================================
+
+// ImportInfo is the repository of an import path resolved by ResolveImportPath.
+type ImportInfo struct {
+	ImportPath string `json:"import_path"` // Import path given to resolve
+	Prefix     string `json:"prefix"`      // Import path of the repository root. Such as "golang.org/x/net"
+	VCS        string `json:"vcs"`         // Version control system of go-import. Such as "git". Empty if unknown
+	RepoRoot   string `json:"repo_root"`   // Repository URL of go-import. Such as "https://go.googlesource.com/net"
+	Repository string `json:"repository"`  // URL of the repository on a supported host. Such as "https://github.com/golang/net"
+	Source     string `json:"source"`      // How the repository was resolved. Such as "go-import"
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// ResolveImportPath returns the repository of the import path. It is a wrapper
+// of DefaultClient.ResolveImportPath.
+func ResolveImportPath(ctx context.Context, importPath string) (*ImportInfo, error) {
+	return DefaultClient.ResolveImportPath(ctx, importPath)
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// ResolveImportPath returns the repository of the import path. Such as
+// "go.uber.org/zap" to "https://github.com/uber-go/zap".
+//
+// It is resolved in the following order and the first one found is returned:
+//
+//  1. The import path under github.com or bitbucket.org. The repository is the
+//     first 2 elements of the path and no request is sent. The other hosts of
+//     Providers, such as GitLab with the nested groups, are resolved by 3.
+//  2. The rules of gopkg.in. Such as "gopkg.in/yaml.v3" to go-yaml/yaml and
+//     "gopkg.in/user/pkg.v1" to user/pkg on GitHub.
+//  3. The go-import and go-source meta tags of "https://<path>?go-get=1" as the
+//     go command does. Following the redirects. The repo-root of go-import or
+//     the home of go-source under the host of Providers is used.
+//  4. The repository field of pkg.go.dev.
+func (c *Client) ResolveImportPath(ctx context.Context, importPath string) (*ImportInfo, error) {
+	importPath = strings.Trim(importPath, "/")
+	elements := strings.Split(importPath, "/")
+
+	if _, ok := c.getRepoProvider(elements[0]); ok && hasFixedRepoRoot(elements[0]) && len(elements) >= 3 {
+		prefix := strings.Join(elements[:3], "/")
+
+		return &ImportInfo{
+			ImportPath: importPath,
+			Prefix:     prefix,
+			Repository: "https://" + prefix,
+			Source:     SourceHost,
+		}, nil
+	}
+
+	if info, ok := resolveGopkgIn(importPath); ok {
+		return info, nil
+	}
+
+	if info, err := c.resolveGoImport(ctx, importPath); err == nil {
+		return info, nil
+	}
+
+	pkgInfo := &PkgInfo{Name: importPath, client: c}
+
+	if err := pkgInfo.UpdateURLRepositoryContext(ctx); err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve import path %s", importPath)
+	}
+
+	return &ImportInfo{
+		ImportPath: importPath,
+		Repository: pkgInfo.Repository,
+		Source:     SourcePkgGoDev,
+	}, nil
+}
+
+// resolveGoImport returns the repository from the meta tags of the vanity
+// import path. It returns an error if not found or not under the host of
+// Providers.
+func (c *Client) resolveGoImport(ctx context.Context, importPath string) (*ImportInfo, error) {
+	content, err := c.GetContentURLContext(ctx, "https://"+importPath+"?go-get=1")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get go-import meta tags")
+	}
+
+	query, err := NewQuery(content)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse go-import meta tags")
+	}
+
+	info := &ImportInfo{ImportPath: importPath}
+	homes := []string{}
+
+	query.Find("meta").Each(func(_ int, s *goquery.Selection) {
+		fields := strings.Fields(s.AttrOr("content", ""))
+
+		// Use the longest prefix of the import path as the go command does
+		if len(fields) < 3 || !hasPathPrefix(importPath, fields[0]) || len(fields[0]) < len(info.Prefix) {
+			return
+		}
+
+		switch s.AttrOr("name", "") {
+		case "go-import":
+			// "mod" is a module proxy. Not a repository
+			if fields[1] != "mod" {
+				info.Prefix, info.VCS, info.RepoRoot = fields[0], fields[1], fields[2]
+			}
+		case "go-source":
+			homes = append(homes, fields[1])
+		}
+	})
+
+	if info.RepoRoot == "" {
+		return nil, errors.Errorf("no go-import meta tag for %s", importPath)
+	}
+
+	for _, candidate := range append([]string{info.RepoRoot}, homes...) {
+		urlInfo, err := NewURLInfo(candidate)
+		if err != nil || urlInfo.Host == "" || len(urlInfo.Path) < 2 {
+			continue
+		}
+
+		if _, ok := c.getRepoProvider(urlInfo.Host); ok {
+			info.Repository = urlInfo.String()
+			info.Source = SourceGoImport
+
+			return info, nil
+		}
+	}
+
+	return nil, errors.Errorf("repository of %s is not on the supported hosts: %s", importPath, info.RepoRoot)
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// hasPathPrefix returns true if prefix is the import path itself or one of its
+// parents.
+func hasPathPrefix(importPath, prefix string) bool {
+	return importPath == prefix || strings.HasPrefix(importPath, prefix+"/")
+}
+
+// hasFixedRepoRoot returns true if the repository root of the host is always
+// "<host>/<owner>/<name>". Unlike GitLab which allows the nested groups.
+func hasFixedRepoRoot(host string) bool {
+	return host == "github.com" || host == "bitbucket.org"
+}
+
+// resolveGopkgIn returns the GitHub repository of the gopkg.in import path.
+// Such as "gopkg.in/yaml.v3" to "https://github.com/go-yaml/yaml".
+func resolveGopkgIn(importPath string) (*ImportInfo, bool) {
+	match := reGopkgIn.FindStringSubmatch(importPath)
+	if match == nil {
+		return nil, false
+	}
+
+	owner, name := match[1], match[2]
+	if owner == "" {
+		owner = "go-" + name
+	}
+
+	// The import path up to the version. Such as "gopkg.in/user/pkg.v1"
+	prefix := strings.Join(strings.Split(importPath, "/")[:2], "/")
+	if match[1] != "" {
+		prefix = strings.Join(strings.Split(importPath, "/")[:3], "/")
+	}
+
+	return &ImportInfo{
+		ImportPath: importPath,
+		Prefix:     prefix,
+		VCS:        "git",
+		RepoRoot:   "https://" + prefix,
+		Repository: "https://github.com/" + owner + "/" + name,
+		Source:     SourceGopkgIn,
+	}, true
+}
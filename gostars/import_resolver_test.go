@@ -0,0 +1,161 @@
+package gostars_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  ResolveImportPath
+// ----------------------------------------------------------------------------
+
+// newVanityClient returns a Client whose all HTTPS requests are sent to a mock
+// server of the vanity import paths regardless of the host. Such as
+// "https://go.uber.org/zap?go-get=1" to "/zap?go-get=1" of the server.
+func newVanityClient(t *testing.T) *gostars.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/zap", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head>
+<meta name="go-import" content="go.uber.org/zap git https://github.com/uber-go/zap.git">
+</head></html>`)
+	})
+
+	// Repository not on the supported hosts but go-source is
+	mux.HandleFunc("/x/net/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head>
+<meta name="go-import" content="golang.org/x/net mod https://proxy.golang.org">
+<meta name="go-import" content="golang.org/x git https://go.googlesource.com/x">
+<meta name="go-import" content="golang.org/x/net git https://go.googlesource.com/net">
+<meta name="go-source" content="golang.org/x/net https://github.com/golang/net/ https://github.com/golang/net/tree/master{/dir} https://github.com/golang/net/blob/master{/dir}/{file}#L{line}">
+</head></html>`)
+	})
+
+	// GitLab with the nested groups. Not the first 2 elements of the path
+	mux.HandleFunc("/group/sub/repo/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<meta name="go-import" content="gitlab.com/group/sub/repo git https://gitlab.com/group/sub/repo.git">`)
+	})
+
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new-place?go-get=1", http.StatusMovedPermanently)
+	})
+
+	mux.HandleFunc("/new-place", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<meta name="go-import" content="go.example.com/moved git https://gitlab.com/KEINOS/moved">`)
+	})
+
+	// Neither go-import nor go-source is on the supported hosts
+	mux.HandleFunc("/self-hosted", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<meta name="go-import" content="go.example.com/self-hosted git https://git.example.org/foo/bar">`)
+	})
+
+	mux.HandleFunc("/pkg/go.example.com/self-hosted", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<div class="UnitMeta-repo"><a href="https://git.example.org/foo/bar">git.example.org/foo/bar</a></div>`)
+	})
+
+	server := httptest.NewTLSServer(mux)
+
+	t.Cleanup(server.Close)
+
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // mock server
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+
+	client := gostars.NewClient()
+
+	client.HTTPClient = &http.Client{Transport: transport}
+	client.PkgGoDevBaseURL = server.URL + "/pkg"
+	client.RateLimiter = nil
+
+	return client
+}
+
+func TestClient_ResolveImportPath(t *testing.T) {
+	client := newVanityClient(t)
+
+	for _, test := range []struct {
+		importPath string
+		expect     gostars.ImportInfo
+	}{
+		{
+			importPath: "github.com/KEINOS/dev-go/sub/pkg",
+			expect: gostars.ImportInfo{
+				Prefix: "github.com/KEINOS/dev-go", Repository: "https://github.com/KEINOS/dev-go",
+				Source: gostars.SourceHost,
+			},
+		},
+		{
+			importPath: "gopkg.in/yaml.v3",
+			expect: gostars.ImportInfo{
+				Prefix: "gopkg.in/yaml.v3", VCS: "git", RepoRoot: "https://gopkg.in/yaml.v3",
+				Repository: "https://github.com/go-yaml/yaml", Source: gostars.SourceGopkgIn,
+			},
+		},
+		{
+			importPath: "gopkg.in/KEINOS/foo.v1-unstable/sub",
+			expect: gostars.ImportInfo{
+				Prefix: "gopkg.in/KEINOS/foo.v1-unstable", VCS: "git", RepoRoot: "https://gopkg.in/KEINOS/foo.v1-unstable",
+				Repository: "https://github.com/KEINOS/foo", Source: gostars.SourceGopkgIn,
+			},
+		},
+		{
+			importPath: "go.uber.org/zap",
+			expect: gostars.ImportInfo{
+				Prefix: "go.uber.org/zap", VCS: "git", RepoRoot: "https://github.com/uber-go/zap.git",
+				Repository: "https://github.com/uber-go/zap", Source: gostars.SourceGoImport,
+			},
+		},
+		{
+			importPath: "golang.org/x/net/html",
+			expect: gostars.ImportInfo{
+				Prefix: "golang.org/x/net", VCS: "git", RepoRoot: "https://go.googlesource.com/net",
+				Repository: "https://github.com/golang/net", Source: gostars.SourceGoImport,
+			},
+		},
+		{
+			importPath: "go.example.com/moved",
+			expect: gostars.ImportInfo{
+				Prefix: "go.example.com/moved", VCS: "git", RepoRoot: "https://gitlab.com/KEINOS/moved",
+				Repository: "https://gitlab.com/KEINOS/moved", Source: gostars.SourceGoImport,
+			},
+		},
+		{
+			importPath: "gitlab.com/group/sub/repo/pkg",
+			expect: gostars.ImportInfo{
+				Prefix: "gitlab.com/group/sub/repo", VCS: "git", RepoRoot: "https://gitlab.com/group/sub/repo.git",
+				Repository: "https://gitlab.com/group/sub/repo", Source: gostars.SourceGoImport,
+			},
+		},
+		{
+			importPath: "go.example.com/self-hosted",
+			expect: gostars.ImportInfo{
+				Repository: "https://git.example.org/foo/bar", Source: gostars.SourcePkgGoDev,
+			},
+		},
+	} {
+		info, err := client.ResolveImportPath(context.Background(), test.importPath)
+		require.NoError(t, err, test.importPath)
+
+		test.expect.ImportPath = test.importPath
+
+		assert.Equal(t, test.expect, *info, test.importPath)
+	}
+
+	// Unknown everywhere
+	_, err := client.ResolveImportPath(context.Background(), "go.example.com/unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve import path go.example.com/unknown")
+}
@@ -0,0 +1,136 @@
+package gostars
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/module"
+)
+
+// errNoModule is the error of ResolveModulePath when the proxy knows none of
+// the candidates. Such as the packages out of modules.
+var errNoModule = errors.New("no module found")
+
+// minModuleDepth is the number of the path elements of the shortest module path
+// requested if the repository root is unknown. The host alone is not a module.
+const minModuleDepth = 2
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// ResolveModulePath returns the path of the module that provides the package.
+// It is a wrapper of DefaultClient.ResolveModulePath.
+func ResolveModulePath(ctx context.Context, importPath string) (string, error) {
+	return DefaultClient.ResolveModulePath(ctx, importPath)
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// ResolveModulePath returns the path of the module that provides the package
+// of the import path. Such as "github.com/aws/aws-sdk-go-v2/service/s3/types"
+// to "github.com/aws/aws-sdk-go-v2/service/s3".
+//
+// As the go command does, the latest version of the import path and of its
+// parents are requested to the Go module proxy from the longest one, down to
+// the repository root resolved by ResolveImportPath. The first path that the
+// proxy knows is the module. If the repository root is unknown, such as
+// resolved by pkg.go.dev, the parents down to "<host>/<element>" are requested.
+// If the client has a Cache, both the known and the unknown paths are cached.
+func (c *Client) ResolveModulePath(ctx context.Context, importPath string) (string, error) {
+	repoRoot := ""
+
+	if info, err := c.ResolveImportPath(ctx, importPath); err == nil {
+		repoRoot = info.Prefix
+	}
+
+	return c.resolveModulePath(ctx, importPath, repoRoot)
+}
+
+// resolveModulePath is the same as ResolveModulePath but with the known import
+// path of the repository root. The parents shorter than the root are not
+// requested.
+func (c *Client) resolveModulePath(ctx context.Context, importPath, repoRoot string) (string, error) {
+	importPath = strings.Trim(importPath, "/")
+	elements := strings.Split(importPath, "/")
+
+	minDepth := minModuleDepth
+	if repoRoot != "" && hasPathPrefix(importPath, repoRoot) {
+		minDepth = len(strings.Split(repoRoot, "/"))
+	}
+
+	for i := len(elements); i >= minDepth; i-- {
+		candidate := strings.Join(elements[:i], "/")
+
+		found, err := c.isModule(ctx, candidate)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to resolve module path of %s", importPath)
+		}
+
+		if found {
+			return candidate, nil
+		}
+	}
+
+	return "", errors.Wrap(errNoModule, importPath)
+}
+
+// isModule returns true if the Go module proxy has the latest version of the
+// path. The "not found" and "gone" responses are false and the other failures
+// are errors, which are not cached.
+func (c *Client) isModule(ctx context.Context, modulePath string) (bool, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid module path")
+	}
+
+	urlLatest := c.getGoProxyBaseURL() + "/" + escaped + "/@latest"
+	key := "module:" + urlLatest
+	found := false
+
+	if entry := c.Cache.load(key); c.Cache.isFresh(entry) && entry.decode(&found) == nil {
+		return found, nil
+	}
+
+	if err := c.waitRateLimit(ctx, hostName(urlLatest)); err != nil {
+		return false, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlLatest, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create request")
+	}
+
+	response, err := c.getHTTPClient().Do(request)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to request the Go module proxy")
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		latest := struct {
+			Version string `json:"Version"`
+		}{}
+
+		if err := json.NewDecoder(response.Body).Decode(&latest); err != nil || latest.Version == "" {
+			return false, errors.New("malformed response of the Go module proxy")
+		}
+
+		found = true
+	case http.StatusNotFound, http.StatusGone:
+		found = false
+	default:
+		return false, errors.Errorf("the Go module proxy returned status: %v", response.StatusCode)
+	}
+
+	c.Cache.store(key, "", found)
+
+	return found, nil
+}
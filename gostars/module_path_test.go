@@ -0,0 +1,114 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  ResolveModulePath
+// ----------------------------------------------------------------------------
+
+// addModuleHandlers adds the mocks of the Go module proxy and pkg.go.dev for the
+// nested module "tools" of dev-go. The other paths are not modules.
+func addModuleHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/proxy/github.com/!k!e!i!n!o!s/dev-go/@latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Version": "v1.0.6", "Time": "2022-01-02T03:04:05Z"}`)
+	})
+
+	mux.HandleFunc("/proxy/github.com/!k!e!i!n!o!s/dev-go/tools/@latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Version": "v0.1.0", "Time": "2022-01-02T03:04:05Z"}`)
+	})
+
+	mux.HandleFunc("/pkg/github.com/KEINOS/dev-go/tools", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body>
+<div class="u-breakWord">github.com/foo/bar</div>
+<div class="u-breakWord">github.com/hoge/fuga</div>
+<div class="u-breakWord">github.com/piyo/piyo</div>
+<div class="u-breakWord">github.com/piyo/hoge</div>
+<div class="u-breakWord">github.com/fuga/piyo</div>
+</body></html>`)
+	})
+
+	mux.HandleFunc("/pkg/github.com/KEINOS/dev-go/tools/lint", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body>
+<div class="u-breakWord">github.com/foo/bar</div>
+</body></html>`)
+	})
+}
+
+func TestClient_ResolveModulePath(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	for _, test := range []struct {
+		importPath string
+		expect     string
+	}{
+		{"github.com/KEINOS/dev-go", "github.com/KEINOS/dev-go"},
+		{"github.com/KEINOS/dev-go/sub/pkg", "github.com/KEINOS/dev-go"},
+		{"github.com/KEINOS/dev-go/tools/lint", "github.com/KEINOS/dev-go/tools"},
+	} {
+		modulePath, err := client.ResolveModulePath(context.Background(), test.importPath)
+		require.NoError(t, err, test.importPath)
+
+		assert.Equal(t, test.expect, modulePath, test.importPath)
+	}
+
+	_, err := client.ResolveModulePath(context.Background(), "github.com/KEINOS/undefined")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no module found")
+
+	// Server error is not "not found"
+	client.GoProxyBaseURL = server.URL + "/content/hello.txt?"
+
+	_, err = client.ResolveModulePath(context.Background(), "github.com/KEINOS/dev-go")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve module path of github.com/KEINOS/dev-go")
+}
+
+func TestClient_ResolveModulePath_repository_root(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+	counts := countRequests(t, server)
+
+	_, err := client.ResolveModulePath(context.Background(), "github.com/KEINOS/undefined/sub")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no module found")
+
+	assert.Equal(t, 1, counts["/proxy/github.com/!k!e!i!n!o!s/undefined/sub/@latest"])
+	assert.Equal(t, 1, counts["/proxy/github.com/!k!e!i!n!o!s/undefined/@latest"])
+	assert.Zero(t, counts["/proxy/github.com/!k!e!i!n!o!s/@latest"], "the parents of the repository root should not be requested")
+	assert.Zero(t, counts["/proxy/github.com/@latest"], "the host should not be requested")
+}
+
+func TestClient_ResolveModulePath_invalid_path(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	_, err := client.ResolveModulePath(context.Background(), "github.com/KEINOS/dev go")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid module path")
+}
+
+func TestClient_ResolveModulePath_cache(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+	client.Cache = gostars.NewCache(t.TempDir(), time.Hour)
+	counts := countRequests(t, server)
+
+	for i := 0; i < 2; i++ {
+		modulePath, err := client.ResolveModulePath(context.Background(), "github.com/KEINOS/dev-go/sub/pkg")
+		require.NoError(t, err)
+
+		assert.Equal(t, "github.com/KEINOS/dev-go", modulePath)
+	}
+
+	assert.Equal(t, 3, sumRequests(counts, "/proxy/"), "both the found and the not found paths should be cached")
+}
@@ -1,20 +1,42 @@
 package gostars
 
 import (
+	"context"
+	"strings"
+
 	pkggodevclient "github.com/guseggert/pkggodev-client"
 	"github.com/pkg/errors"
 )
 
+// Levels to count the importers for the gravity. See PkgInfo.GetImportedBy.
+const (
+	LevelPackage = "package" // Importers of the package itself
+	LevelModule  = "module"  // Importers of the root package of the module
+	LevelRepo    = "repo"    // Importers of the root package of the repository
+)
+
 // ============================================================================
 //  Type: PkgInfo
 // ============================================================================
 
 // PkgInfo holds information about the package from pkg.go.dev.
 // It is mainly used to obtain the number of packages using this package.
+//
+// For the package "github.com/aws/aws-sdk-go-v2/service/s3/types", Module is
+// "github.com/aws/aws-sdk-go-v2/service/s3", RepoRoot is
+// "github.com/aws/aws-sdk-go-v2" and Subdir is "service/s3/types".
 type PkgInfo struct {
-	Name       string `json:"name"`        // Name of the package
-	Repository string `json:"repository"`  // Repository URL of the package
-	ImportedBy int    `json:"imported_by"` // Number of packages that imports this package
+	Name             string `json:"name"`               // Name of the package. Which is the package path
+	Repository       string `json:"repository"`         // Repository URL of the package
+	Module           string `json:"module"`             // Path of the module that provides the package
+	RepoRoot         string `json:"repo_root"`          // Import path of the repository root
+	Subdir           string `json:"subdir"`             // Directory of the package in the repository. Empty if the root
+	ImportedBy       int    `json:"imported_by"`        // Number of packages that imports this package
+	ModuleImportedBy int    `json:"module_imported_by"` // Number of packages that imports the root package of the module
+	RepoImportedBy   int    `json:"repo_imported_by"`   // Number of packages that imports the root package of the repository
+
+	client *Client // Client to access the remote services. DefaultClient is used if nil
+	cached bool    // True if the fields came from the cache of the client
 }
 
 // ============================================================================
@@ -22,37 +44,161 @@ type PkgInfo struct {
 // ============================================================================
 
 // NewPkgInfo returns the initialized object of PkgInfo from pkagName.
+//
+// It is a wrapper of DefaultClient.NewPkgInfo.
 func NewPkgInfo(pkgName string) (*PkgInfo, error) {
-	pkgInfo := &PkgInfo{
-		Name: pkgName,
-	}
+	return DefaultClient.NewPkgInfo(pkgName)
+}
 
-	if err := pkgInfo.Update(); err != nil {
-		return nil, err
-	}
+// NewPkgInfoContext is the same as NewPkgInfo but with a context to cancel the
+// requests.
+func NewPkgInfoContext(ctx context.Context, pkgName string) (*PkgInfo, error) {
+	return DefaultClient.NewPkgInfoContext(ctx, pkgName)
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
 
-	return pkgInfo, nil
+// ListLevels returns the levels available for Client.Level.
+func ListLevels() []string {
+	return []string{LevelPackage, LevelModule, LevelRepo}
 }
 
 // ============================================================================
 //  Methods
 // ============================================================================
 
+// GetImportedBy returns the number of the importers at the level. Such as
+// ModuleImportedBy for LevelModule. ImportedBy is returned for the unknown
+// levels.
+func (p *PkgInfo) GetImportedBy(level string) int {
+	switch level {
+	case LevelModule:
+		return p.ModuleImportedBy
+	case LevelRepo:
+		return p.RepoImportedBy
+	}
+
+	return p.ImportedBy
+}
+
 // Update pulls the package information and sets to the according field.
-func (p *PkgInfo) Update() (err error) {
-	CoolDown()
+func (p *PkgInfo) Update() error {
+	return p.UpdateContext(context.Background())
+}
+
+// UpdateContext is the same as Update but with a context to cancel the requests.
+//
+// The repository is resolved by Client.ResolveImportPath. Which requests the
+// repository to pkg.go.dev only if the vanity import path could not be
+// resolved. If the client has a Cache, the fresh entry is used instead of
+// requesting.
+//
+// The module and its importers are updated only if the Level of the client is
+// LevelModule or LevelRepo. Since they cost more requests. See
+// UpdateModuleContext.
+func (p *PkgInfo) UpdateContext(ctx context.Context) (err error) {
+	client := p.getClient()
+	level := client.getLevel()
+	key := "pkg:" + client.getPkgGoDevBaseURL() + "/" + p.Name
+
+	if level != LevelPackage {
+		key += "@" + level
+	}
+
+	if entry := client.Cache.load(key); client.Cache.isFresh(entry) && entry.decode(p) == nil {
+		p.cached = true
 
-	if err = p.UpdateImportedBy(); err == nil {
-		err = p.UpdateURLRepository()
+		return nil
+	}
+
+	p.cached = false
+
+	if err = p.UpdateImportedByContext(ctx); err == nil {
+		var info *ImportInfo
+
+		if info, err = client.ResolveImportPath(ctx, p.Name); err == nil {
+			p.Repository = info.Repository
+			p.RepoRoot = info.Prefix
+			p.Subdir = subdirOf(p.Name, p.RepoRoot)
+
+			if level != LevelPackage {
+				err = p.UpdateModuleContext(ctx)
+			}
+		}
+	}
+
+	if err == nil {
+		client.Cache.store(key, "", p)
 	}
 
 	return err
 }
 
+// UpdateModule updates the module, the subdirectory and the importers of the
+// module and the repository root.
+func (p *PkgInfo) UpdateModule() error {
+	return p.UpdateModuleContext(context.Background())
+}
+
+// UpdateModuleContext is the same as UpdateModule but with a context to cancel
+// the requests.
+//
+// The module is resolved by Client.ResolveModulePath down to RepoRoot. If none
+// of the paths is a module, such as the packages out of modules, the repository
+// root is assumed as the module. If RepoRoot is empty, such as resolved by pkg.go.dev, the module is
+// assumed as the repository root.
+//
+// The importers are requested to pkg.go.dev only if the path differs from the
+// package. If the root is not a package, the number of the lower level is used.
+// Such as ImportedBy for ModuleImportedBy.
+func (p *PkgInfo) UpdateModuleContext(ctx context.Context) error {
+	client := p.getClient()
+
+	module, err := client.resolveModulePath(ctx, p.Name, p.RepoRoot)
+	if err != nil {
+		if !errors.Is(err, errNoModule) {
+			return err
+		}
+
+		module = p.RepoRoot
+	}
+
+	if module == "" {
+		module = p.Name
+	}
+
+	if p.RepoRoot == "" || !hasPathPrefix(module, p.RepoRoot) {
+		p.RepoRoot = module
+	}
+
+	p.Module = module
+	p.Subdir = subdirOf(p.Name, p.RepoRoot)
+
+	if p.ModuleImportedBy, err = p.countImportedBy(ctx, p.Module, p.Name, p.ImportedBy); err != nil {
+		return err
+	}
+
+	p.RepoImportedBy, err = p.countImportedBy(ctx, p.RepoRoot, p.Module, p.ModuleImportedBy)
+
+	return err
+}
+
 // UpdateImportedBy updates the imported number by other packages if the package
 // name is a valid package in pkg.go.dev.
 func (p *PkgInfo) UpdateImportedBy() error {
-	client := pkggodevclient.New()
+	return p.UpdateImportedByContext(context.Background())
+}
+
+// UpdateImportedByContext is the same as UpdateImportedBy but with a context to
+// cancel the request.
+func (p *PkgInfo) UpdateImportedByContext(ctx context.Context) error {
+	if err := p.waitRateLimit(ctx); err != nil {
+		return err
+	}
+
+	client := p.getClient().newPkgGoDevClient(ctx)
 
 	// Set ImportedBy
 	importedBy, err := client.ImportedBy(pkggodevclient.ImportedByRequest{
@@ -70,7 +216,17 @@ func (p *PkgInfo) UpdateImportedBy() error {
 // UpdateURLRepository adds "https://" to the repository if the package name
 // is a valid package in pkg.go.dev.
 func (p *PkgInfo) UpdateURLRepository() error {
-	client := pkggodevclient.New()
+	return p.UpdateURLRepositoryContext(context.Background())
+}
+
+// UpdateURLRepositoryContext is the same as UpdateURLRepository but with a
+// context to cancel the request.
+func (p *PkgInfo) UpdateURLRepositoryContext(ctx context.Context) error {
+	if err := p.waitRateLimit(ctx); err != nil {
+		return err
+	}
+
+	client := p.getClient().newPkgGoDevClient(ctx)
 
 	pkgInfo, err := client.DescribePackage(pkggodevclient.DescribePackageRequest{
 		Package: p.Name,
@@ -83,3 +239,65 @@ func (p *PkgInfo) UpdateURLRepository() error {
 
 	return nil
 }
+
+// countImportedBy returns the number of the importers of the package path.
+// It returns fallback if the path is the same as the lower level or failed to
+// get the number. Except the context is done.
+func (p *PkgInfo) countImportedBy(ctx context.Context, path, lower string, fallback int) (int, error) {
+	if path == lower {
+		return fallback, nil
+	}
+
+	pkgInfo := &PkgInfo{Name: path, client: p.client}
+
+	if err := pkgInfo.UpdateImportedByContext(ctx); err != nil {
+		if ctx.Err() != nil {
+			return 0, err
+		}
+
+		return fallback, nil
+	}
+
+	return pkgInfo.ImportedBy, nil
+}
+
+func (p *PkgInfo) getClient() *Client {
+	if p.client == nil {
+		return DefaultClient
+	}
+
+	return p.client
+}
+
+// waitRateLimit blocks until a request to pkg.go.dev is allowed.
+func (p *PkgInfo) waitRateLimit(ctx context.Context) error {
+	client := p.getClient()
+
+	return client.waitRateLimit(ctx, hostName(client.getPkgGoDevBaseURL()))
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// subdirOf returns the directory of the package in the repository. Such as
+// "service/s3" for "github.com/aws/aws-sdk-go-v2/service/s3". It is empty for
+// the repository root or the unknown root.
+func subdirOf(namePkg, repoRoot string) string {
+	if repoRoot == "" || !hasPathPrefix(namePkg, repoRoot) {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(namePkg, repoRoot), "/")
+}
+
+// isLevel returns true if level is one of ListLevels.
+func isLevel(level string) bool {
+	for _, l := range ListLevels() {
+		if l == level {
+			return true
+		}
+	}
+
+	return false
+}
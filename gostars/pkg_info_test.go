@@ -0,0 +1,77 @@
+package gostars_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  PkgInfo
+// ----------------------------------------------------------------------------
+
+func TestPkgInfo_bad_package_name(t *testing.T) {
+	t.Cleanup(useDefaultClient(newFakeClient(newFakeServer(t))))
+
+	namePkg := "github.com/KEINOS/undefined"
+
+	pkgInfo, err := gostars.NewPkgInfo(namePkg)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get 'imported by' information")
+	assert.Nil(t, pkgInfo)
+}
+
+func TestClient_NewPkgInfo_module(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+	counts := countRequests(t, server)
+
+	// The module is not resolved at the package level
+	pkgInfo, err := client.NewPkgInfo("github.com/KEINOS/dev-go/tools/lint")
+	require.NoError(t, err)
+
+	assert.Empty(t, pkgInfo.Module)
+	assert.Equal(t, "github.com/KEINOS/dev-go", pkgInfo.RepoRoot)
+	assert.Equal(t, "tools/lint", pkgInfo.Subdir)
+	assert.Zero(t, sumRequests(counts, "/proxy/"), "it should not request the Go module proxy at the package level")
+
+	client.Level = gostars.LevelRepo
+
+	// Root package of the repository
+	pkgInfo, err = client.NewPkgInfo("github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, "github.com/KEINOS/dev-go", pkgInfo.Module)
+	assert.Equal(t, "github.com/KEINOS/dev-go", pkgInfo.RepoRoot)
+	assert.Empty(t, pkgInfo.Subdir)
+	assert.Equal(t, 2, pkgInfo.ModuleImportedBy)
+	assert.Equal(t, 2, pkgInfo.RepoImportedBy)
+
+	// Package in a nested module
+	pkgInfo, err = client.NewPkgInfo("github.com/KEINOS/dev-go/tools/lint")
+	require.NoError(t, err)
+
+	assert.Equal(t, "github.com/KEINOS/dev-go/tools", pkgInfo.Module)
+	assert.Equal(t, "github.com/KEINOS/dev-go", pkgInfo.RepoRoot)
+	assert.Equal(t, "tools/lint", pkgInfo.Subdir)
+	assert.Equal(t, "https://github.com/KEINOS/dev-go", pkgInfo.Repository)
+	assert.Equal(t, 1, pkgInfo.GetImportedBy(gostars.LevelPackage))
+	assert.Equal(t, 5, pkgInfo.GetImportedBy(gostars.LevelModule))
+	assert.Equal(t, 2, pkgInfo.GetImportedBy(gostars.LevelRepo))
+	assert.Equal(t, 1, pkgInfo.GetImportedBy("unknown"))
+}
+
+func TestUpdateURLRepository_fail(t *testing.T) {
+	t.Cleanup(useDefaultClient(newFakeClient(newFakeServer(t))))
+
+	pkgInfo := &gostars.PkgInfo{
+		Name: "github.com/KEINOS/undefined",
+	}
+
+	err := pkgInfo.UpdateURLRepository()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get package information")
+}
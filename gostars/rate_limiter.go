@@ -0,0 +1,199 @@
+package gostars
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+//  Type: RateLimit
+// ============================================================================
+
+// RateLimit is the setting of the token bucket of a host.
+type RateLimit struct {
+	Rate  float64 // Number of requests allowed per second. No limit if 0 or less
+	Burst int     // Number of requests allowed at once
+}
+
+// ============================================================================
+//  Type: RateLimiter
+// ============================================================================
+
+// RateLimiter throttles the requests with a token bucket per host. The search
+// API of GitHub has its own bucket, "api.github.com/search".
+//
+// The buckets of GitHub API adapt its rate from the "X-RateLimit-Remaining" and
+// "X-RateLimit-Reset" headers given via Observe. It is safe for concurrent use.
+type RateLimiter struct {
+	Limits  map[string]RateLimit // Rate limits per host name or bucket. Such as "api.github.com"
+	Default RateLimit            // Rate limit for the hosts not in Limits
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// ============================================================================
+//  Constructor
+// ============================================================================
+
+// NewRateLimiter returns a new RateLimiter with the default limits of the known
+// hosts.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		Limits: map[string]RateLimit{
+			// Authenticated request: 5,000 req/hour ≅ 1.4 req/sec. It adapts
+			// to the actual remaining after the first response.
+			"api.github.com": {Rate: 5000.0 / 3600.0, Burst: 5},
+			// Search API of GitHub has its own limit: 30 req/minute.
+			"api.github.com/search": {Rate: 30.0 / 60.0, Burst: 5},
+			// Scraping. Be polite.
+			"pkg.go.dev": {Rate: 1, Burst: 2},
+			// Static contents
+			"raw.githubusercontent.com": {Rate: 5, Burst: 5},
+			// Go module proxy. Served from CDN
+			"proxy.golang.org": {Rate: 10, Burst: 10},
+		},
+		Default: RateLimit{Rate: 1, Burst: 1},
+	}
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// Observe adapts the rate of the host from the remaining number of requests and
+// the time when the limit resets. Such as the rate limit headers of GitHub API.
+//
+// The remaining requests will be spread until the reset time. If remaining is 0,
+// the next request waits until the reset time.
+func (l *RateLimiter) Observe(host string, remaining int, reset time.Time) {
+	untilReset := time.Until(reset).Seconds()
+	if untilReset <= 0 || remaining < 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.getBucket(host, time.Now())
+
+	if remaining == 0 {
+		b.tokens = math.Min(b.tokens, 0)
+		b.rate = 1 / untilReset
+
+		return
+	}
+
+	b.tokens = math.Min(b.tokens, float64(remaining))
+	b.rate = math.Min(b.limit.Rate, float64(remaining)/untilReset)
+}
+
+// Wait blocks until a request to the host is allowed or ctx is done. In the
+// latter case, it returns the error of ctx.
+func (l *RateLimiter) Wait(ctx context.Context, host string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	b := l.getBucket(host, time.Now())
+	delay := b.reserve(time.Now())
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Give back the reserved token
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// getBucket returns the bucket of the host. It must be called with the lock.
+func (l *RateLimiter) getBucket(host string, now time.Time) *bucket {
+	if l.buckets == nil {
+		l.buckets = map[string]*bucket{}
+	}
+
+	if b, ok := l.buckets[host]; ok {
+		return b
+	}
+
+	limit, ok := l.Limits[host]
+	if !ok {
+		limit = l.Default
+	}
+
+	b := &bucket{
+		limit:  limit,
+		rate:   limit.Rate,
+		tokens: float64(limit.Burst),
+		last:   now,
+	}
+
+	l.buckets[host] = b
+
+	return b
+}
+
+// ============================================================================
+//  Type: bucket
+// ============================================================================
+
+// bucket is a token bucket of a host.
+type bucket struct {
+	limit  RateLimit // Initial setting
+	rate   float64   // Current number of tokens added per second
+	tokens float64   // Current number of tokens. Negative if reserved in advance
+	last   time.Time // Last time the tokens were added
+}
+
+// reserve takes a token and returns the duration to wait until the token is
+// available.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	if b.limit.Rate <= 0 {
+		return 0
+	}
+
+	burst := math.Max(float64(b.limit.Burst), 1)
+	elapsed := now.Sub(b.last).Seconds()
+
+	b.tokens = math.Min(burst, b.tokens+elapsed*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// hostName returns the host name of rawURL without the port number. It returns
+// rawURL as is if it fails to parse.
+func hostName(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+
+	return parsed.Hostname()
+}
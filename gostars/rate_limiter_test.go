@@ -0,0 +1,114 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  RateLimiter
+// ----------------------------------------------------------------------------
+
+func TestRateLimiter_Wait_burst(t *testing.T) {
+	limiter := gostars.NewRateLimiter()
+	limiter.Limits["example.com"] = gostars.RateLimit{Rate: 5, Burst: 2}
+
+	ctx := context.Background()
+	start := time.Now()
+
+	// Burst
+	require.NoError(t, limiter.Wait(ctx, "example.com"))
+	require.NoError(t, limiter.Wait(ctx, "example.com"))
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "requests within the burst should not wait")
+
+	// Over the burst. 5 req/sec = 200ms/req
+	require.NoError(t, limiter.Wait(ctx, "example.com"))
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond, "request over the burst should wait")
+
+	// Other hosts have their own bucket
+	start = time.Now()
+
+	require.NoError(t, limiter.Wait(ctx, "example.net"))
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "buckets should be separated per host")
+}
+
+func TestRateLimiter_Wait_no_limit(t *testing.T) {
+	limiter := &gostars.RateLimiter{} // zero value has no limit
+	start := time.Now()
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Wait(context.Background(), "example.com"))
+	}
+
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRateLimiter_Wait_canceled(t *testing.T) {
+	limiter := gostars.NewRateLimiter()
+	limiter.Limits["example.com"] = gostars.RateLimit{Rate: 0.1, Burst: 1}
+
+	require.NoError(t, limiter.Wait(context.Background(), "example.com"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Next token is available in 10 seconds
+	err := limiter.Wait(ctx, "example.com")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	limiter := gostars.NewRateLimiter()
+
+	// No remaining. It should wait until the reset time.
+	limiter.Observe("api.github.com", 0, time.Now().Add(300*time.Millisecond))
+
+	start := time.Now()
+
+	require.NoError(t, limiter.Wait(context.Background(), "api.github.com"))
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond, "it should wait until the reset time")
+
+	// Past reset time should be ignored
+	limiter.Observe("example.com", 0, time.Now().Add(-time.Hour))
+
+	start = time.Now()
+
+	require.NoError(t, limiter.Wait(context.Background(), "example.com"))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestClient_NewRepoInfo_observe_rate_limit(t *testing.T) {
+	reset := time.Now().Add(2 * time.Second) // The header is in seconds
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+
+		fmt.Fprint(w, `{"name": "dev-go"}`)
+	}))
+	defer server.Close()
+
+	client := gostars.NewClient()
+
+	client.HTTPClient = server.Client()
+	client.GitHubBaseURL = server.URL
+
+	_, err := client.NewRepoInfo("https://github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	// The next request to the host must wait until the reset
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = client.RateLimiter.Wait(ctx, "127.0.0.1")
+	require.ErrorIs(t, err, context.DeadlineExceeded, "it should adapt to the rate limit headers")
+}
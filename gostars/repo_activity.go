@@ -0,0 +1,297 @@
+package gostars
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	"github.com/pkg/errors"
+)
+
+// Periods of the activity metrics.
+const (
+	daysActivity  = 90  // Period of the recent activity in days
+	daysFreshness = 365 // Period of the freshness in days
+	hoursPerDay   = 24
+	durationWeek  = 7 * hoursPerDay * time.Hour
+)
+
+// ============================================================================
+//  Type: RepoActivity
+// ============================================================================
+
+// RepoActivity holds the activity metrics of a repository on GitHub.
+//
+// The commits and code frequency are from the statistics of GitHub which are
+// computed in the background. If they are not computed yet, Pending is true
+// and they are 0.
+type RepoActivity struct {
+	Commits90d    int             `json:"commits_90d"`    // Number of commits in the last 90 days
+	CodeFrequency []CodeFrequency `json:"code_frequency"` // Weekly additions and deletions in the last 90 days
+	Releases      int             `json:"releases"`       // Number of releases
+	LastRelease   time.Time       `json:"last_release"`   // Time of the latest release. Zero if no release
+	Pending       bool            `json:"pending"`        // True if the statistics are not computed by GitHub yet
+}
+
+// CodeFrequency is the number of lines added and deleted in a week.
+type CodeFrequency struct {
+	Week      time.Time `json:"week"`      // Start of the week
+	Additions int       `json:"additions"` // Number of lines added
+	Deletions int       `json:"deletions"` // Number of lines deleted. Positive number
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// AverageCodeFrequency returns the average number of lines added and deleted
+// per week.
+func (a *RepoActivity) AverageCodeFrequency() float64 {
+	if len(a.CodeFrequency) == 0 {
+		return 0
+	}
+
+	sum := 0
+
+	for _, week := range a.CodeFrequency {
+		sum += week.Additions + week.Deletions
+	}
+
+	return float64(sum) / float64(len(a.CodeFrequency))
+}
+
+// SinceLastRelease returns the duration since the latest release. It returns 0
+// if there is no release.
+func (a *RepoActivity) SinceLastRelease() time.Duration {
+	if a.LastRelease.IsZero() {
+		return 0
+	}
+
+	return time.Since(a.LastRelease)
+}
+
+// UpdateActivity retrieves the activity metrics of the repository from GitHub
+// and sets them to the Activity field.
+func (r *RepoInfo) UpdateActivity() error {
+	return r.UpdateActivityContext(context.Background())
+}
+
+// UpdateActivityContext is the same as UpdateActivity but with a context to
+// cancel the requests.
+//
+// It costs 3 requests to GitHub API. Such as the commit activity, the code
+// frequency and the releases. If the client has a Cache, the fresh entry is
+// used instead.
+func (r *RepoInfo) UpdateActivityContext(ctx context.Context) error {
+	if !r.isGitHub() {
+		return errors.Errorf("activity is available only for GitHub repositories: %s", r.URL.Host)
+	}
+
+	client := r.getClient()
+	key := "activity:" + client.getGitHubBaseURL() + "repos/" + r.Owner + "/" + r.Name
+	activity := new(RepoActivity)
+
+	// The pending statistics are not cached to retry on the next run
+	if entry := client.Cache.load(key); client.Cache.isFresh(entry) && entry.decode(activity) == nil {
+		r.Activity = activity
+
+		return nil
+	}
+
+	clientGitHub, err := client.newGitHubClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := r.updateCommitActivity(ctx, clientGitHub, activity); err != nil {
+		return err
+	}
+
+	if err := r.updateCodeFrequency(ctx, clientGitHub, activity); err != nil {
+		return err
+	}
+
+	if err := r.updateReleases(ctx, clientGitHub, activity); err != nil {
+		return err
+	}
+
+	if !activity.Pending {
+		client.Cache.store(key, "", activity)
+	}
+
+	r.Activity = activity
+
+	return nil
+}
+
+// requestGitHub waits for the rate limit and calls the request function. Then
+// observes the rate limit from the response.
+func (r *RepoInfo) requestGitHub(ctx context.Context, request func() (*github.Response, error)) error {
+	client := r.getClient()
+
+	return client.requestGitHub(ctx, hostName(client.getGitHubBaseURL()), request)
+}
+
+// requestGitHubSearch is the same as requestGitHub but for the search API. The
+// search API has its own rate limit, so it has its own bucket. Such as
+// "api.github.com/search".
+func (r *RepoInfo) requestGitHubSearch(ctx context.Context, request func() (*github.Response, error)) error {
+	client := r.getClient()
+
+	return client.requestGitHub(ctx, hostName(client.getGitHubBaseURL())+"/search", request)
+}
+
+// requestGitHub waits for the rate limit of the bucket and calls the request
+// function. Then observes the rate limit of the bucket from the response.
+func (c *Client) requestGitHub(ctx context.Context, bucket string, request func() (*github.Response, error)) error {
+	if err := c.waitRateLimit(ctx, bucket); err != nil {
+		return err
+	}
+
+	resp, err := request()
+
+	// Adapt to the rate limit of GitHub even on error
+	c.observeRateLimit(bucket, resp)
+
+	return err
+}
+
+func (r *RepoInfo) updateCodeFrequency(ctx context.Context, client *github.Client, activity *RepoActivity) error {
+	var weeks []*github.WeeklyStats
+
+	err := r.requestGitHub(ctx, func() (resp *github.Response, err error) {
+		weeks, resp, err = client.Repositories.ListCodeFrequency(ctx, r.Owner, r.Name)
+
+		return resp, err
+	})
+
+	if isAccepted(err) {
+		activity.Pending = true
+
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to get code frequency")
+	}
+
+	activity.CodeFrequency = []CodeFrequency{}
+	since := time.Now().AddDate(0, 0, -daysActivity)
+
+	for _, week := range weeks {
+		start := week.GetWeek().Time
+		if start.Add(durationWeek).Before(since) {
+			continue
+		}
+
+		deletions := week.GetDeletions()
+		if deletions < 0 {
+			deletions = -deletions
+		}
+
+		activity.CodeFrequency = append(activity.CodeFrequency, CodeFrequency{
+			Week:      start,
+			Additions: week.GetAdditions(),
+			Deletions: deletions,
+		})
+	}
+
+	return nil
+}
+
+func (r *RepoInfo) updateCommitActivity(ctx context.Context, client *github.Client, activity *RepoActivity) error {
+	var weeks []*github.WeeklyCommitActivity
+
+	err := r.requestGitHub(ctx, func() (resp *github.Response, err error) {
+		weeks, resp, err = client.Repositories.ListCommitActivity(ctx, r.Owner, r.Name)
+
+		return resp, err
+	})
+
+	if isAccepted(err) {
+		activity.Pending = true
+
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to get commit activity")
+	}
+
+	since := time.Now().AddDate(0, 0, -daysActivity)
+
+	for _, week := range weeks {
+		if week.GetWeek().Time.Add(durationWeek).After(since) {
+			activity.Commits90d += week.GetTotal()
+		}
+	}
+
+	return nil
+}
+
+// updateReleases sets the number of releases and the time of the latest one.
+// To count the releases with a single request, it requests 1 release per page
+// and reads the number of the last page.
+func (r *RepoInfo) updateReleases(ctx context.Context, client *github.Client, activity *RepoActivity) error {
+	var (
+		releases []*github.RepositoryRelease
+		lastPage int
+	)
+
+	err := r.requestGitHub(ctx, func() (resp *github.Response, err error) {
+		releases, resp, err = client.Repositories.ListReleases(ctx, r.Owner, r.Name, &github.ListOptions{PerPage: 1})
+		if resp != nil {
+			lastPage = resp.LastPage
+		}
+
+		return resp, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get releases")
+	}
+
+	activity.Releases = len(releases)
+	if lastPage > activity.Releases {
+		activity.Releases = lastPage
+	}
+
+	if len(releases) > 0 {
+		activity.LastRelease = releases[0].GetPublishedAt().Time
+		if activity.LastRelease.IsZero() {
+			activity.LastRelease = releases[0].GetCreatedAt().Time
+		}
+	}
+
+	return nil
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// getFreshness returns 365 minus the days since the time. It returns 0 if the
+// time is zero or older than a year.
+func getFreshness(since time.Time) float64 {
+	if since.IsZero() {
+		return 0
+	}
+
+	days := time.Since(since).Hours() / hoursPerDay
+	if days > daysFreshness {
+		return 0
+	}
+
+	if days < 0 {
+		return daysFreshness
+	}
+
+	return daysFreshness - days
+}
+
+// isAccepted returns true if the error is "202 Accepted" of GitHub API. Which
+// means the statistics are being computed in the background.
+func isAccepted(err error) bool {
+	var accepted *github.AcceptedError
+
+	return errors.As(err, &accepted)
+}
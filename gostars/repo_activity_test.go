@@ -0,0 +1,166 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  RepoActivity
+// ----------------------------------------------------------------------------
+
+// addActivityHandlers adds the mocks of the activity of "KEINOS/dev-go" to the
+// fake GitHub API. The week 1 year ago should be ignored.
+func addActivityHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/api/repos/KEINOS/dev-go/stats/commit_activity", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"total": 100, "week": %d}, {"total": 5, "week": %d}]`,
+			time.Now().AddDate(-1, 0, 0).Unix(), time.Now().AddDate(0, 0, -7).Unix())
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/dev-go/stats/code_frequency", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[[%d, 1000, -1000], [%d, 100, -50], [%d, 20, -30]]`,
+			time.Now().AddDate(-1, 0, 0).Unix(), time.Now().AddDate(0, 0, -14).Unix(), time.Now().AddDate(0, 0, -7).Unix())
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/dev-go/releases", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<`+r.URL.Path+`?page=7&per_page=1>; rel="last"`)
+		fmt.Fprintf(w, `[{"tag_name": "v1.0.6", "published_at": %q}]`,
+			time.Now().AddDate(0, 0, -65).Format(time.RFC3339))
+	})
+}
+
+func TestRepoInfo_UpdateActivity(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	repoInfo, err := client.NewRepoInfo("https://github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC), repoInfo.LastPush.UTC())
+	assert.Nil(t, repoInfo.Activity, "activity should not be fetched by default")
+
+	require.NoError(t, repoInfo.UpdateActivity())
+	require.NotNil(t, repoInfo.Activity)
+
+	activity := repoInfo.Activity
+
+	assert.False(t, activity.Pending)
+	assert.Equal(t, 5, activity.Commits90d, "it should count the last 90 days only")
+	require.Len(t, activity.CodeFrequency, 2, "it should have the last 90 days only")
+	assert.Equal(t, 50, activity.CodeFrequency[0].Deletions, "deletions should be positive")
+	assert.Equal(t, 100.0, activity.AverageCodeFrequency())
+	assert.Equal(t, 7, activity.Releases, "it should be the number of the last page")
+	assert.InDelta(t, 65*24, activity.SinceLastRelease().Hours(), 1)
+}
+
+func TestRepoInfo_UpdateActivity_pending(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/stats/"):
+			// Statistics are being computed
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, "{}")
+		case strings.HasSuffix(r.URL.Path, "/releases"):
+			fmt.Fprint(w, "[]")
+		default:
+			fmt.Fprint(w, `{"name": "bar", "stargazers_count": 1}`)
+		}
+	}))
+	defer server.Close()
+
+	client := newFakeClient(server)
+	client.GravityModel = &gostars.GravityModel{
+		Weights: map[string]float64{
+			gostars.DimStars:            1,
+			gostars.DimCommits90d:       1,
+			gostars.DimReleases:         1,
+			gostars.DimReleaseFreshness: 1,
+		},
+	}
+
+	repoInfo, err := client.NewRepoInfo("https://github.com/foo/bar")
+	require.NoError(t, err)
+	require.NoError(t, repoInfo.UpdateActivity())
+
+	assert.True(t, repoInfo.Activity.Pending)
+	assert.Zero(t, repoInfo.Activity.Releases)
+	assert.True(t, repoInfo.Activity.LastRelease.IsZero())
+	assert.Zero(t, repoInfo.Activity.SinceLastRelease())
+
+	breakdown := client.GravityModel.Explain(&gostars.Score{Pkg: &gostars.PkgInfo{}, Repo: repoInfo})
+	require.NotNil(t, breakdown)
+
+	for _, dim := range breakdown.Dimensions {
+		assert.Equal(t, dim.Name != gostars.DimCommits90d, dim.Available,
+			"pending statistics should not be available: %s", dim.Name)
+	}
+}
+
+func TestClient_ScorePackage_activity(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	// The model requires the activity
+	client.GravityModel = &gostars.GravityModel{
+		Weights: map[string]float64{gostars.DimCommits90d: 1, gostars.DimReleases: 1},
+	}
+
+	score, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	require.NotNil(t, score.Repo.Activity)
+	assert.Equal(t, gostars.GetAttractionGravity(5, 7), score.Gravity)
+
+	// Push freshness needs no activity. The last push of the mock is too old
+	client.GravityModel = &gostars.GravityModel{
+		Weights: map[string]float64{gostars.DimPushFreshness: 1},
+	}
+
+	score, err = client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Nil(t, score.Repo.Activity)
+	assert.Zero(t, score.Gravity)
+}
+
+func TestClient_ScorePackage_activity_fail(t *testing.T) {
+	server := newFakeServer(t)
+	handler := server.Config.Handler
+
+	// GitHub returns 422 for the statistics of the repositories with 10k+ commits
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats/commit_activity") {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+
+	client := newFakeClient(server)
+	client.Activity = true
+
+	score, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err, "the activity only requested by the option should not fail the score")
+
+	assert.Nil(t, score.Repo.Activity)
+	require.Len(t, score.Warnings, 1)
+	assert.Contains(t, score.Warnings[0], "failed to get activity")
+	assert.Equal(t, gostars.GetAttractionGravity(10, 3, 2, 2), score.Gravity)
+
+	// The model weights the activity
+	client.GravityModel = &gostars.GravityModel{
+		Weights: map[string]float64{gostars.DimCommits90d: 1},
+	}
+
+	_, err = client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.Error(t, err, "the activity weighted by the model should fail the score")
+}
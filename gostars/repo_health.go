@@ -0,0 +1,489 @@
+package gostars
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	"github.com/pkg/errors"
+)
+
+// Settings of the health metrics.
+const (
+	daysHealthWindow = 180 // Default window of the issues and pull requests to sample in days
+	daysStale        = 90  // Open issues not updated for this days are stale
+	maxHealthItems   = 100 // Maximum number of the issues and pull requests to sample
+	maxHealthPages   = 3   // Maximum number of the pages of the comments to fetch
+)
+
+// ============================================================================
+//  Type: RepoHealth
+// ============================================================================
+
+// RepoHealth holds the metrics of how the maintainers respond to the issues and
+// pull requests of a repository on GitHub.
+//
+// The counts of the issues are of the whole repository. The medians are of the
+// latest issues and pull requests (up to 100) created in the window.
+type RepoHealth struct {
+	WindowDays   int         `json:"window_days"`   // Window of the sampled issues and pull requests in days
+	OpenIssues   int         `json:"open_issues"`   // Number of the open issues. Pull requests are not included
+	ClosedIssues int         `json:"closed_issues"` // Number of the closed issues. Pull requests are not included
+	StaleIssues  int         `json:"stale_issues"`  // Number of the open issues not updated in the last 90 days
+	StaleRatio   float64     `json:"stale_ratio"`   // StaleIssues per OpenIssues. 0..1. 0 if no open issue
+	Issues       HealthStats `json:"issues"`        // Response of the issues created in the window
+	PullRequests HealthStats `json:"pull_requests"` // Response of the pull requests created in the window
+}
+
+// HealthStats is the response of the maintainers to the issues or the pull
+// requests created in the window.
+//
+// The first response is the first comment, review or review comment by other
+// than the author and bots.
+// The items without a response or not closed are not included in the medians.
+// The durations are in nanoseconds in JSON.
+type HealthStats struct {
+	Created             int           `json:"created"`               // Number of the items created in the window
+	Responded           int           `json:"responded"`             // Number of the items responded
+	Closed              int           `json:"closed"`                // Number of the items closed
+	MedianFirstResponse time.Duration `json:"median_first_response"` // Median time from the creation to the first response
+	MedianClose         time.Duration `json:"median_close"`          // Median time from the creation to close
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// UpdateHealth retrieves the health metrics of the repository from GitHub and
+// sets them to the Health field.
+func (r *RepoInfo) UpdateHealth() error {
+	return r.UpdateHealthContext(context.Background())
+}
+
+// UpdateHealthContext is the same as UpdateHealth but with a context to cancel
+// the requests.
+//
+// The window is HealthWindow of the client. It usually costs up to 7 requests
+// to GitHub API. Such as 3 searches of the issue counts, the latest issues and
+// up to 3 pages of the comments. If the comments are more than 3 pages, the
+// comments of the sampled items with no response found are requested per item.
+// If pull requests are sampled, up to 3 pages of the review comments and the
+// reviews per pull request are also requested.
+// Note that the search API has a lower rate limit than the others. If the
+// client has a Cache, the fresh entry is used instead.
+func (r *RepoInfo) UpdateHealthContext(ctx context.Context) error {
+	if !r.isGitHub() {
+		return errors.Errorf("health is available only for GitHub repositories: %s", r.URL.Host)
+	}
+
+	client := r.getClient()
+	window := client.getHealthWindow()
+	key := fmt.Sprintf("health:%srepos/%s/%s?window=%d", client.getGitHubBaseURL(), r.Owner, r.Name, window)
+	health := new(RepoHealth)
+
+	if entry := client.Cache.load(key); client.Cache.isFresh(entry) && entry.decode(health) == nil {
+		r.Health = health
+
+		return nil
+	}
+
+	clientGitHub, err := client.newGitHubClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	health.WindowDays = int(window / (hoursPerDay * time.Hour))
+
+	if err := r.updateIssueCounts(ctx, clientGitHub, health); err != nil {
+		return err
+	}
+
+	if err := r.updateHealthStats(ctx, clientGitHub, health, time.Now().Add(-window)); err != nil {
+		return err
+	}
+
+	client.Cache.store(key, "", health)
+
+	r.Health = health
+
+	return nil
+}
+
+// countIssues returns the total count of the search query of the issues.
+func (r *RepoInfo) countIssues(ctx context.Context, client *github.Client, query string) (int, error) {
+	var result *github.IssuesSearchResult
+
+	query = fmt.Sprintf("repo:%s/%s %s", r.Owner, r.Name, query)
+
+	err := r.requestGitHubSearch(ctx, func() (resp *github.Response, err error) {
+		result, resp, err = client.Search.Issues(ctx, query, &github.SearchOptions{
+			ListOptions: github.ListOptions{PerPage: 1},
+		})
+
+		return resp, err
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to search issues: %s", query)
+	}
+
+	return result.GetTotal(), nil
+}
+
+// listFirstResponses returns the time of the first response per issue number
+// of the sampled issues and pull requests. The responses by the author and bots
+// are ignored.
+//
+// The comments of the repository are listed from the creation of the oldest
+// sampled item. If they are more than maxHealthPages pages, the comments of the
+// sampled items with no response found are listed per item. The reviews and
+// the review comments of the pull requests are also responses.
+func (r *RepoInfo) listFirstResponses(
+	ctx context.Context, client *github.Client, sampled []*github.Issue,
+) (map[int]time.Time, error) {
+	authors := map[int]string{}
+	since := time.Now()
+
+	for _, item := range sampled {
+		authors[item.GetNumber()] = item.GetUser().GetLogin()
+
+		if created := item.GetCreatedAt(); created.Before(since) {
+			since = created
+		}
+	}
+
+	responses := map[int]time.Time{}
+
+	// Issue number 0 lists the comments of all the issues in the repository
+	complete, err := r.addFirstResponses(ctx, client, 0, since, maxHealthPages, authors, responses)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range sampled {
+		if _, ok := responses[item.GetNumber()]; complete || ok || item.GetComments() == 0 {
+			continue
+		}
+
+		if _, err := r.addFirstResponses(ctx, client, item.GetNumber(), since, 1, authors, responses); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.addFirstReviews(ctx, client, sampled, since, authors, responses); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// addFirstResponses lists the comments of the issue number since the time, up
+// to maxPages pages, and adds the first responses to the responses. Number 0 is
+// of all the issues. It returns true if all the comments are listed.
+func (r *RepoInfo) addFirstResponses(
+	ctx context.Context, client *github.Client, number int, since time.Time, maxPages int,
+	authors map[int]string, responses map[int]time.Time,
+) (bool, error) {
+	opts := &github.IssueListCommentsOptions{
+		Sort:        github.String("created"),
+		Direction:   github.String("asc"),
+		Since:       &since,
+		ListOptions: github.ListOptions{PerPage: maxHealthItems},
+	}
+
+	for page := 0; page < maxPages; page++ {
+		var comments []*github.IssueComment
+
+		nextPage := 0
+
+		err := r.requestGitHub(ctx, func() (resp *github.Response, err error) {
+			comments, resp, err = client.Issues.ListComments(ctx, r.Owner, r.Name, number, opts)
+			if resp != nil {
+				nextPage = resp.NextPage
+			}
+
+			return resp, err
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get comments")
+		}
+
+		for _, comment := range comments {
+			numberIssue := number
+			if numberIssue == 0 {
+				numberIssue = getIssueNumber(comment.GetIssueURL())
+			}
+
+			addFirstResponse(numberIssue, comment.GetUser(), comment.GetCreatedAt(), authors, responses)
+		}
+
+		if nextPage == 0 {
+			return true, nil
+		}
+
+		opts.Page = nextPage
+	}
+
+	return false, nil
+}
+
+// addFirstReviews adds the first responses of the reviews and the review
+// comments to the sampled pull requests.
+//
+// The review comments of the repository are listed since the time, up to
+// maxHealthPages pages. The reviews are listed per pull request, up to a page.
+// Which covers the review comments not listed as they are parts of a review.
+func (r *RepoInfo) addFirstReviews(
+	ctx context.Context, client *github.Client, sampled []*github.Issue, since time.Time,
+	authors map[int]string, responses map[int]time.Time,
+) error {
+	pulls := []*github.Issue{}
+
+	for _, item := range sampled {
+		if item.IsPullRequest() {
+			pulls = append(pulls, item)
+		}
+	}
+
+	if len(pulls) == 0 {
+		return nil
+	}
+
+	opts := &github.PullRequestListCommentsOptions{
+		Sort:        "created",
+		Direction:   "asc",
+		Since:       since,
+		ListOptions: github.ListOptions{PerPage: maxHealthItems},
+	}
+
+	for page := 0; page < maxHealthPages; page++ {
+		var comments []*github.PullRequestComment
+
+		nextPage := 0
+
+		// Number 0 lists the review comments of all the pull requests
+		err := r.requestGitHub(ctx, func() (resp *github.Response, err error) {
+			comments, resp, err = client.PullRequests.ListComments(ctx, r.Owner, r.Name, 0, opts)
+			if resp != nil {
+				nextPage = resp.NextPage
+			}
+
+			return resp, err
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to get review comments")
+		}
+
+		for _, comment := range comments {
+			number := getIssueNumber(comment.GetPullRequestURL())
+
+			addFirstResponse(number, comment.GetUser(), comment.GetCreatedAt(), authors, responses)
+		}
+
+		if nextPage == 0 {
+			break
+		}
+
+		opts.Page = nextPage
+	}
+
+	for _, pull := range pulls {
+		var reviews []*github.PullRequestReview
+
+		err := r.requestGitHub(ctx, func() (resp *github.Response, err error) {
+			reviews, resp, err = client.PullRequests.ListReviews(ctx, r.Owner, r.Name, pull.GetNumber(),
+				&github.ListOptions{PerPage: maxHealthItems})
+
+			return resp, err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get reviews of #%d", pull.GetNumber())
+		}
+
+		for _, review := range reviews {
+			// Pending reviews are not submitted yet
+			if review.SubmittedAt == nil {
+				continue
+			}
+
+			addFirstResponse(pull.GetNumber(), review.GetUser(), review.GetSubmittedAt(), authors, responses)
+		}
+	}
+
+	return nil
+}
+
+func (r *RepoInfo) updateHealthStats(ctx context.Context, client *github.Client, health *RepoHealth, since time.Time) error {
+	var items []*github.Issue
+
+	err := r.requestGitHub(ctx, func() (resp *github.Response, err error) {
+		items, resp, err = client.Issues.ListByRepo(ctx, r.Owner, r.Name, &github.IssueListByRepoOptions{
+			State:       "all",
+			Sort:        "created",
+			Direction:   "desc",
+			ListOptions: github.ListOptions{PerPage: maxHealthItems},
+		})
+
+		return resp, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get issues")
+	}
+
+	// Issues and pull requests created in the window
+	sampled := []*github.Issue{}
+
+	for _, item := range items {
+		if item.GetCreatedAt().Before(since) {
+			continue
+		}
+
+		sampled = append(sampled, item)
+	}
+
+	if len(sampled) == 0 {
+		return nil
+	}
+
+	responses, err := r.listFirstResponses(ctx, client, sampled)
+	if err != nil {
+		return err
+	}
+
+	var issues, pulls healthSample
+
+	for _, item := range sampled {
+		if item.IsPullRequest() {
+			pulls.add(item, responses)
+		} else {
+			issues.add(item, responses)
+		}
+	}
+
+	health.Issues = issues.stats()
+	health.PullRequests = pulls.stats()
+
+	return nil
+}
+
+func (r *RepoInfo) updateIssueCounts(ctx context.Context, client *github.Client, health *RepoHealth) error {
+	var err error
+
+	if health.OpenIssues, err = r.countIssues(ctx, client, "is:issue is:open"); err != nil {
+		return err
+	}
+
+	if health.ClosedIssues, err = r.countIssues(ctx, client, "is:issue is:closed"); err != nil {
+		return err
+	}
+
+	if health.OpenIssues == 0 {
+		return nil
+	}
+
+	staleSince := time.Now().AddDate(0, 0, -daysStale).Format("2006-01-02")
+
+	if health.StaleIssues, err = r.countIssues(ctx, client, "is:issue is:open updated:<"+staleSince); err != nil {
+		return err
+	}
+
+	health.StaleRatio = float64(health.StaleIssues) / float64(health.OpenIssues)
+
+	return nil
+}
+
+// ============================================================================
+//  Type: healthSample (private)
+// ============================================================================
+
+// healthSample collects the durations of the issues or the pull requests to
+// calculate HealthStats.
+type healthSample struct {
+	created   int
+	responses []time.Duration
+	closes    []time.Duration
+}
+
+func (s *healthSample) add(item *github.Issue, responses map[int]time.Time) {
+	created := item.GetCreatedAt()
+
+	s.created++
+
+	if responded, ok := responses[item.GetNumber()]; ok {
+		s.responses = append(s.responses, responded.Sub(created))
+	}
+
+	if item.ClosedAt != nil {
+		s.closes = append(s.closes, item.GetClosedAt().Sub(created))
+	}
+}
+
+func (s *healthSample) stats() HealthStats {
+	return HealthStats{
+		Created:             s.created,
+		Responded:           len(s.responses),
+		Closed:              len(s.closes),
+		MedianFirstResponse: medianDuration(s.responses),
+		MedianClose:         medianDuration(s.closes),
+	}
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// addFirstResponse sets the time to the responses of the issue number if it is
+// the first response. The responses by the author and bots, and to the issues
+// not sampled, are ignored.
+func addFirstResponse(number int, user *github.User, created time.Time, authors map[int]string, responses map[int]time.Time) {
+	author, ok := authors[number]
+	if !ok || user.GetLogin() == author || user.GetType() == "Bot" {
+		return
+	}
+
+	if first, ok := responses[number]; !ok || created.Before(first) {
+		responses[number] = created
+	}
+}
+
+// getIssueNumber returns the issue number from the API URL of the issue or the
+// pull request. Such as "https://api.github.com/repos/owner/repo/issues/123" or
+// ".../pulls/123". It returns 0 if not found.
+func getIssueNumber(urlIssue string) int {
+	for _, kind := range []string{"/issues/", "/pulls/"} {
+		index := strings.LastIndex(urlIssue, kind)
+		if index < 0 {
+			continue
+		}
+
+		number, err := strconv.Atoi(urlIssue[index+len(kind):])
+		if err != nil {
+			return 0
+		}
+
+		return number
+	}
+
+	return 0
+}
+
+// medianDuration returns the median of the durations. It returns 0 if empty.
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration{}, durations...)
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+}
@@ -0,0 +1,277 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  RepoHealth
+// ----------------------------------------------------------------------------
+
+// addHealthHandlers adds the mocks of the health of "KEINOS/dev-go" to the fake
+// GitHub API. Issue #1 is out of the window and #4 is a pull request. The
+// comments by the author and bots, and the pending reviews, should be ignored.
+func addHealthHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/api/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		total := 0
+
+		switch {
+		case strings.Contains(query, "updated:<"):
+			total = 2
+		case strings.Contains(query, "is:open"):
+			total = 4
+		case strings.Contains(query, "is:closed"):
+			total = 6
+		}
+
+		fmt.Fprintf(w, `{"total_count": %d, "items": []}`, total)
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/dev-go/issues", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[
+{"number": 4, "user": {"login": "erin"}, "created_at": %q, "closed_at": %q, "pull_request": {"url": "pulls/4"}},
+{"number": 3, "user": {"login": "carol"}, "created_at": %q},
+{"number": 2, "user": {"login": "alice"}, "created_at": %q, "closed_at": %q},
+{"number": 1, "user": {"login": "alice"}, "created_at": %q}
+]`, ago(5*24), ago(4*24), ago(10*24), ago(20*24), ago(18*24), ago(400*24))
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/dev-go/issues/comments", func(w http.ResponseWriter, r *http.Request) {
+		urlIssue := "http://" + r.Host + "/api/repos/KEINOS/dev-go/issues/"
+
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next"`)
+			fmt.Fprintf(w, `[
+{"issue_url": "%[1]s2", "user": {"login": "alice"}, "created_at": %[2]q},
+{"issue_url": "%[1]s2", "user": {"login": "bob"}, "created_at": %[3]q},
+{"issue_url": "%[1]s3", "user": {"login": "dependabot[bot]", "type": "Bot"}, "created_at": %[4]q}
+]`, urlIssue, ago(20*24-1), ago(20*24-2), ago(10*24-1))
+
+			return
+		}
+
+		fmt.Fprintf(w, `[
+{"issue_url": "%[1]s4", "user": {"login": "bob"}, "created_at": %[2]q},
+{"issue_url": "%[1]s3", "user": {"login": "dave"}, "created_at": %[3]q},
+{"issue_url": "%[1]s1", "user": {"login": "bob"}, "created_at": %[3]q}
+]`, urlIssue, ago(5*24-3), ago(10*24-6))
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/dev-go/pulls/comments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"pull_request_url": "http://%s/api/repos/KEINOS/dev-go/pulls/4", "user": {"login": "erin"}, "created_at": %q}]`,
+			r.Host, ago(5*24-1))
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/dev-go/pulls/4/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[
+{"user": {"login": "carol"}, "state": "PENDING"},
+{"user": {"login": "dave"}, "state": "APPROVED", "submitted_at": %q}
+]`, ago(5*24-4))
+	})
+}
+
+func TestRepoInfo_UpdateHealth(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	repoInfo, err := client.NewRepoInfo("https://github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Nil(t, repoInfo.Health, "health should not be fetched by default")
+
+	require.NoError(t, repoInfo.UpdateHealth())
+	require.NotNil(t, repoInfo.Health)
+
+	health := repoInfo.Health
+
+	assert.Equal(t, 180, health.WindowDays)
+	assert.Equal(t, 4, health.OpenIssues)
+	assert.Equal(t, 6, health.ClosedIssues)
+	assert.Equal(t, 2, health.StaleIssues)
+	assert.Equal(t, 0.5, health.StaleRatio)
+
+	// #2 responded in 2h and closed in 48h. #3 responded in 6h and open
+	assert.Equal(t, gostars.HealthStats{
+		Created:             2,
+		Responded:           2,
+		Closed:              1,
+		MedianFirstResponse: 4 * time.Hour,
+		MedianClose:         48 * time.Hour,
+	}, health.Issues)
+
+	// #4 responded in 3h and closed in 24h
+	assert.Equal(t, gostars.HealthStats{
+		Created:             1,
+		Responded:           1,
+		Closed:              1,
+		MedianFirstResponse: 3 * time.Hour,
+		MedianClose:         24 * time.Hour,
+	}, health.PullRequests)
+}
+
+func TestRepoInfo_UpdateHealth_reviews(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/repos/KEINOS/reviewed", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "reviewed"}`)
+	})
+
+	mux.HandleFunc("/api/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_count": 0, "items": []}`)
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/reviewed/issues", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[
+{"number": 2, "user": {"login": "alice"}, "created_at": %q, "comments": 1, "pull_request": {"url": "pulls/2"}},
+{"number": 1, "user": {"login": "alice"}, "created_at": %q, "pull_request": {"url": "pulls/1"}}
+]`, ago(5*24), ago(10*24))
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/reviewed/issues/comments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"issue_url": "http://%s/api/repos/KEINOS/reviewed/issues/2", "user": {"login": "bob"}, "created_at": %q}]`,
+			r.Host, ago(5*24-5))
+	})
+
+	// #1 is first responded by the review comment
+	mux.HandleFunc("/api/repos/KEINOS/reviewed/pulls/comments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"pull_request_url": "http://%s/api/repos/KEINOS/reviewed/pulls/1", "user": {"login": "bob"}, "created_at": %q}]`,
+			r.Host, ago(10*24-2))
+	})
+
+	// #2 is first responded by the review, before the comment
+	mux.HandleFunc("/api/repos/KEINOS/reviewed/pulls/2/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"user": {"login": "bob"}, "state": "CHANGES_REQUESTED", "submitted_at": %q}]`, ago(5*24-1))
+	})
+
+	mux.HandleFunc("/api/repos/KEINOS/reviewed/pulls/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"user": {"login": "bob"}, "state": "COMMENTED", "submitted_at": %q}]`, ago(10*24-6))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newFakeClient(server)
+
+	repoInfo, err := client.NewRepoInfo("https://github.com/KEINOS/reviewed")
+	require.NoError(t, err)
+	require.NoError(t, repoInfo.UpdateHealth())
+
+	// #2 responded in 1h by the review and #1 in 2h by the review comment
+	assert.Equal(t, gostars.HealthStats{
+		Created:             2,
+		Responded:           2,
+		MedianFirstResponse: 90 * time.Minute,
+	}, repoInfo.Health.PullRequests)
+	assert.Zero(t, repoInfo.Health.Issues.Created)
+}
+
+func TestRepoInfo_UpdateHealth_many_comments(t *testing.T) {
+	var since []string
+
+	oldest := ago(10 * 24)
+	requests := map[string]int{}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/api/repos/KEINOS/busy":
+			fmt.Fprint(w, `{"name": "busy"}`)
+		case "/api/search/issues":
+			// Search has its own rate limit
+			w.Header().Set("X-RateLimit-Limit", "30")
+			w.Header().Set("X-RateLimit-Remaining", "1")
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(time.Hour).Unix()))
+			fmt.Fprint(w, `{"total_count": 0, "items": []}`)
+		case "/api/repos/KEINOS/busy/issues":
+			// #3 is out of the window
+			fmt.Fprintf(w, `[
+{"number": 2, "user": {"login": "alice"}, "created_at": %q, "comments": 120},
+{"number": 1, "user": {"login": "alice"}, "created_at": %q, "comments": 0},
+{"number": 3, "user": {"login": "alice"}, "created_at": %q, "comments": 1}
+]`, ago(5*24), oldest, ago(400*24))
+		case "/api/repos/KEINOS/busy/issues/comments":
+			// Endless comments by the author
+			since = append(since, r.URL.Query().Get("since"))
+
+			w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next"`)
+			fmt.Fprintf(w, `[{"issue_url": "http://%s/api/repos/KEINOS/busy/issues/2", "user": {"login": "alice"}, "created_at": %q}]`,
+				r.Host, ago(5*24-1))
+		case "/api/repos/KEINOS/busy/issues/2/comments":
+			fmt.Fprintf(w, `[{"user": {"login": "bob"}, "created_at": %q}]`, ago(5*24-2))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newFakeClient(server)
+	client.RateLimiter = &gostars.RateLimiter{
+		Limits: map[string]gostars.RateLimit{
+			"127.0.0.1":        {Rate: 100, Burst: 100},
+			"127.0.0.1/search": {Rate: 100, Burst: 100},
+		},
+	}
+
+	repoInfo, err := client.NewRepoInfo("https://github.com/KEINOS/busy")
+	require.NoError(t, err)
+	require.NoError(t, repoInfo.UpdateHealth())
+
+	// The comments are listed from the creation of the oldest sampled item
+	require.NotEmpty(t, since)
+	assert.Equal(t, oldest, since[0])
+	assert.Equal(t, 3, requests["/api/repos/KEINOS/busy/issues/comments"])
+
+	// The rest of #2 is listed per item. #1 has no comment to list
+	assert.Equal(t, 1, requests["/api/repos/KEINOS/busy/issues/2/comments"])
+	assert.Zero(t, requests["/api/repos/KEINOS/busy/issues/1/comments"])
+	assert.Equal(t, gostars.HealthStats{
+		Created:             2,
+		Responded:           1,
+		MedianFirstResponse: 2 * time.Hour,
+	}, repoInfo.Health.Issues)
+
+	// The searches used up the search bucket only
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, client.RateLimiter.Wait(ctx, "127.0.0.1"))
+	require.ErrorIs(t, client.RateLimiter.Wait(ctx, "127.0.0.1/search"), context.DeadlineExceeded)
+}
+
+func TestClient_ScorePackage_health(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	client.Health = true
+
+	score, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	require.NotNil(t, score.Repo.Health)
+	assert.Equal(t, 4, score.Repo.Health.OpenIssues)
+	assert.Empty(t, score.Warnings)
+
+	// Narrow window. The items older than a week are not sampled
+	client.HealthWindow = 7 * 24 * time.Hour
+
+	score, err = client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, score.Repo.Health.WindowDays)
+	assert.Zero(t, score.Repo.Health.Issues.Created)
+	assert.Equal(t, 1, score.Repo.Health.PullRequests.Created)
+}
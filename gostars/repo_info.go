@@ -2,130 +2,120 @@ package gostars
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"time"
 
 	"github.com/pkg/errors"
-
-	"github.com/google/go-github/v42/github"
-	"golang.org/x/oauth2"
 )
 
 // ============================================================================
 //  Type: RepoInfo
 // ============================================================================
 
-// RepoInfo contains information about the repository on GitHub.
-// It is mainly used to retrieve the number of stars, forks, followers, etc.
-// from the repository.
+// RepoInfo contains information about the repository on GitHub, GitLab,
+// Bitbucket or Gitea. It is mainly used to retrieve the number of stars, forks,
+// followers, etc. from the repository. See RepoProvider for the differences
+// between the hosts.
 type RepoInfo struct {
-	URL         *URLInfo `json:"url"`         // Parsed URL info of the repo
-	Description string   `json:"description"` // Desctiption of the repo
-	Name        string   `json:"name"`        // Name of the repo
-	Owner       string   `json:"owner"`       // Name of the repo owner
-	Stars       int      `json:"stars"`       // Number of stars of the repo
-	Forks       int      `json:"forks"`       // Number of forked repo of the repo
-	Followers   int      `json:"followers"`   // Number of watching people
+	URL         *URLInfo  `json:"url"`         // Parsed URL info of the repo
+	Description string    `json:"description"` // Desctiption of the repo
+	Name        string    `json:"name"`        // Name of the repo
+	Owner       string    `json:"owner"`       // Name of the repo owner
+	Stars       int       `json:"stars"`       // Number of stars of the repo
+	Forks       int       `json:"forks"`       // Number of forked repo of the repo
+	Followers   int       `json:"followers"`   // Number of watching people
+	LastPush    time.Time `json:"last_push"`   // Time of the last push to the repo
+
+	// Activity metrics of the repo. Nil unless UpdateActivity was called
+	Activity *RepoActivity `json:"activity,omitempty"`
+
+	// Health metrics of the issues and pull requests. Nil unless UpdateHealth
+	// was called
+	Health *RepoHealth `json:"health,omitempty"`
+
+	client *Client // Client to access the remote services. DefaultClient is used if nil
+	cached bool    // True if the fields came from the cache of the client
 }
 
 // ============================================================================
 //  Constructor
 // ============================================================================
 
-// NewRepoInfo returns the initialized object of RepoInfo from the given GitHub's URL.
+// NewRepoInfo returns the initialized object of RepoInfo from the given URL of
+// the repository.
+//
+// It is a wrapper of DefaultClient.NewRepoInfo.
 func NewRepoInfo(urlRepo string) (*RepoInfo, error) {
-	// Get the actual URL of the GitHub repository from the mapping. (URLAliases)
-	urlRepo = GetURLGitHub(urlRepo)
-
-	urlInfo, err := NewURLInfo(urlRepo)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get URL information")
-	}
-
-	repoInfo := &RepoInfo{
-		URL: urlInfo,
-	}
-
-	if !strings.Contains(urlRepo, "https://github.com/") {
-		return nil, errors.New("the URL must be under GitHub host")
-	}
-
-	nameOwner, err := repoInfo.getNameOwner()
-	if err != nil {
-		return nil, err
-	}
-
-	repoInfo.Owner = nameOwner
-
-	nameRepo, err := repoInfo.getNameRepo()
-	if err != nil {
-		return nil, err
-	}
-
-	repoInfo.Name = nameRepo
-
-	// Update other field
-	if err := repoInfo.Update(); err != nil {
-		return nil, errors.Wrap(err, "failed to update repository info")
-	}
+	return DefaultClient.NewRepoInfo(urlRepo)
+}
 
-	return repoInfo, nil
+// NewRepoInfoContext is the same as NewRepoInfo but with a context to cancel the
+// requests.
+func NewRepoInfoContext(ctx context.Context, urlRepo string) (*RepoInfo, error) {
+	return DefaultClient.NewRepoInfoContext(ctx, urlRepo)
 }
 
 // ============================================================================
 //  Methods
 // ============================================================================
 
-// Update retrieves the repository information from GitHub and sets it in the
+// Update retrieves the repository information from the host and sets it in the
 // corresponding field.
 func (r *RepoInfo) Update() error {
-	CoolDown()
+	return r.UpdateContext(context.Background())
+}
 
-	ctx := context.Background()
-	client := github.NewClient(nil)
+// UpdateContext is the same as Update but with a context to cancel the request.
+//
+// The information is retrieved by the RepoProvider of the host of the URL. See
+// DefaultRepoProviders for the supported hosts.
+func (r *RepoInfo) UpdateContext(ctx context.Context) error {
+	client := r.getClient()
+	host := ""
 
-	if GithubToken != "" {
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: GithubToken},
-		)
-		tc := oauth2.NewClient(ctx, ts)
+	if r.URL != nil {
+		host = r.URL.Host
+	}
 
-		client = github.NewClient(tc)
+	provider, ok := client.getRepoProvider(host)
+	if !ok {
+		return errors.Errorf("unsupported host of the repository: %q", host)
 	}
 
-	repo, resp, err := client.Repositories.Get(ctx, r.Owner, r.Name)
-	if err != nil || resp.StatusCode != 200 {
-		msgErr := fmt.Sprintf("faild to get repository info. Returned status: %v", resp.StatusCode)
+	return provider.UpdateRepo(ctx, client, r)
+}
 
-		return errors.Wrap(err, msgErr)
-	}
+// isGitHub returns true if the repository is on GitHub. The RepoInfo without
+// URL is considered as GitHub for the backward compatibility.
+func (r *RepoInfo) isGitHub() bool {
+	return r.URL == nil || r.URL.Host == "" || r.URL.IsRepoGitHub()
+}
 
-	r.Description = repo.GetDescription()
-	r.Stars = repo.GetStargazersCount()
-	r.Forks = repo.GetForksCount()
-	r.Followers = repo.GetSubscribersCount()
+func (r *RepoInfo) getClient() *Client {
+	if r.client == nil {
+		return DefaultClient
+	}
 
-	return nil
+	return r.client
 }
 
 // Owner returns the owner name from the repository URL.
 func (r *RepoInfo) getNameOwner() (string, error) {
-	path := r.URL.Path
+	owner := r.URL.Owner()
 
-	if len(path) < 1 {
+	if owner == "" {
 		return "", errors.New("invalid URL format. missing repo owner and/or repo name")
 	}
 
-	return path[0], nil
+	return owner, nil
 }
 
 // Name returns the name from the repository from the URL.
 func (r *RepoInfo) getNameRepo() (string, error) {
-	path := r.URL.Path
+	name := r.URL.Repo()
 
-	if len(path) < 2 {
+	if name == "" {
 		return "", errors.New("invalid URL format. missing repo owner and/or repo name")
 	}
 
-	return path[1], nil
+	return name, nil
 }
@@ -0,0 +1,73 @@
+package gostars_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  RepoInfo
+// ----------------------------------------------------------------------------
+
+func TestRepoInfo_bad_url(t *testing.T) {
+	t.Cleanup(useDefaultClient(newFakeClient(newFakeServer(t))))
+
+	for _, test := range []struct {
+		url     string
+		contain string
+	}{
+		{"https://foo.bar.com/", "unsupported host of the repository"},
+		{"/KEINOS/dev-go", "unsupported host of the repository"},
+		{"https://github.com/", "missing repo owner and/or repo name"},
+		{"https://github.com/KEINOS", "missing repo owner and/or repo name"},
+		{"https://github.com/KEINOS/" + string([]byte{0x7f}), "invalid control character in URL"},
+		{"https://github.com/KEINOS/undefined", "failed to update repository info"},
+	} {
+		repoInfo, err := gostars.NewRepoInfo(test.url)
+
+		require.Error(t, err, "'%v' should be an error", test.url)
+		assert.Nil(t, repoInfo, "it shuld be nil on error")
+		assert.Contains(t, err.Error(), test.contain)
+	}
+}
+
+func TestRepoInfo_Update_bad_credential(t *testing.T) {
+	var authorization string
+
+	// GitHub API which rejects the token
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message": "Bad credentials"}`)
+	}))
+	defer server.Close()
+
+	t.Cleanup(useDefaultClient(newFakeClient(server)))
+
+	oldGithubToken := gostars.GithubToken
+	defer func() {
+		gostars.GithubToken = oldGithubToken
+	}()
+
+	gostars.GithubToken = "<undefined>"
+
+	repoInfo := gostars.RepoInfo{
+		Name:  "dev-go",
+		Owner: "KEINOS",
+		URL:   new(gostars.URLInfo),
+	}
+
+	err := repoInfo.Update()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "faild to get repository info")
+	assert.Contains(t, err.Error(), "401 Bad credentials")
+	assert.Equal(t, "Bearer <undefined>", authorization, "it should use the token")
+}
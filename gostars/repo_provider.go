@@ -0,0 +1,294 @@
+package gostars
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v42/github"
+	"github.com/pkg/errors"
+)
+
+// Base URLs of the APIs of the code hosting services other than GitHub. The one
+// of GitHub is Client.GitHubBaseURL.
+const (
+	urlGitLabAPIDefault    = "https://gitlab.com/api/v4"
+	urlBitbucketAPIDefault = "https://api.bitbucket.org/2.0"
+	urlCodebergAPIDefault  = "https://codeberg.org/api/v1"
+	urlGiteaAPIDefault     = "https://gitea.com/api/v1"
+)
+
+// ============================================================================
+//  Type: RepoProvider
+// ============================================================================
+
+// RepoProvider retrieves the repository information from a code hosting
+// service. Such as GitHub, GitLab, Bitbucket and Gitea.
+//
+// UpdateRepo fills the common fields of the RepoInfo. Such as Description,
+// Stars, Forks, Followers and LastPush. The Owner and Name are already set.
+// Implementations should request via the client to be throttled and cached as
+// the other requests. Such as Client.GetContentURLContext.
+type RepoProvider interface {
+	UpdateRepo(ctx context.Context, client *Client, repo *RepoInfo) error
+}
+
+// DefaultRepoProviders returns the providers per host of the public code hosting
+// services. Add an entry to Client.Providers to support a self-hosted one. Such
+// as:
+//
+//	client.Providers["git.example.com"] = &GiteaProvider{BaseURL: "https://git.example.com/api/v1"}
+func DefaultRepoProviders() map[string]RepoProvider {
+	return map[string]RepoProvider{
+		"github.com":    &GitHubProvider{},
+		"gitlab.com":    &GitLabProvider{BaseURL: urlGitLabAPIDefault},
+		"bitbucket.org": &BitbucketProvider{BaseURL: urlBitbucketAPIDefault},
+		"codeberg.org":  &GiteaProvider{BaseURL: urlCodebergAPIDefault},
+		"gitea.com":     &GiteaProvider{BaseURL: urlGiteaAPIDefault},
+	}
+}
+
+// ============================================================================
+//  Type: GitHubProvider
+// ============================================================================
+
+// GitHubProvider is the RepoProvider of GitHub. It uses GitHubBaseURL and the
+// token of the client.
+type GitHubProvider struct{}
+
+// UpdateRepo is an implementation of RepoProvider.
+//
+// If the client has a Cache, the fresh entry is used without requesting. The
+// stale entry is revalidated with a conditional request (If-None-Match) which
+// does not consume the rate limit of GitHub API if not modified.
+func (p *GitHubProvider) UpdateRepo(ctx context.Context, client *Client, r *RepoInfo) error {
+	host := hostName(client.getGitHubBaseURL())
+	pathAPI := "repos/" + r.Owner + "/" + r.Name
+	key := "repo:" + client.getGitHubBaseURL() + pathAPI
+	entry := client.Cache.load(key)
+
+	if client.Cache.isFresh(entry) && entry.decode(r) == nil {
+		r.cached = true
+
+		return nil
+	}
+
+	if err := client.waitRateLimit(ctx, host); err != nil {
+		return err
+	}
+
+	clientGitHub, err := client.newGitHubClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := clientGitHub.NewRequest(http.MethodGet, pathAPI, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	if etag := entry.getETag(); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	repo := new(github.Repository)
+	resp, err := clientGitHub.Do(ctx, req, repo)
+
+	// Adapt to the rate limit of GitHub even on error
+	client.observeRateLimit(host, resp)
+
+	// go-github treats "304 Not Modified" as an error
+	if resp != nil && resp.StatusCode == http.StatusNotModified && entry.decode(r) == nil {
+		client.Cache.store(key, entry.ETag, r)
+		r.cached = true
+
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "faild to get repository info")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("faild to get repository info. Returned status: %v", resp.StatusCode)
+	}
+
+	r.Description = repo.GetDescription()
+	r.Stars = repo.GetStargazersCount()
+	r.Forks = repo.GetForksCount()
+	r.Followers = repo.GetSubscribersCount()
+	r.LastPush = repo.GetPushedAt().Time
+	r.cached = false
+
+	client.Cache.store(key, resp.Header.Get("ETag"), r)
+
+	return nil
+}
+
+// ============================================================================
+//  Type: GitLabProvider
+// ============================================================================
+
+// GitLabProvider is the RepoProvider of GitLab.
+//
+// GitLab does not provide the number of the watchers via the API. Thus the
+// Followers is always 0.
+type GitLabProvider struct {
+	BaseURL string // Base URL of GitLab API v4. Such as "https://gitlab.com/api/v4"
+}
+
+// UpdateRepo is an implementation of RepoProvider.
+func (p *GitLabProvider) UpdateRepo(ctx context.Context, client *Client, r *RepoInfo) error {
+	var project struct {
+		Description    string    `json:"description"`
+		StarCount      int       `json:"star_count"`
+		ForksCount     int       `json:"forks_count"`
+		LastActivityAt time.Time `json:"last_activity_at"`
+	}
+
+	// The project can be in the nested groups. Such as "group/sub/repo"
+	pathProject := r.Owner + "/" + r.Name
+	if r.URL != nil && r.URL.Project() != "" {
+		pathProject = r.URL.Project()
+	}
+
+	urlAPI := strings.TrimSuffix(p.BaseURL, "/") + "/projects/" + url.PathEscape(pathProject)
+
+	cached, err := client.getJSON(ctx, urlAPI, &project)
+	if err != nil {
+		return errors.Wrap(err, "faild to get repository info from GitLab")
+	}
+
+	r.Description = project.Description
+	r.Stars = project.StarCount
+	r.Forks = project.ForksCount
+	r.LastPush = project.LastActivityAt
+	r.cached = cached
+
+	return nil
+}
+
+// ============================================================================
+//  Type: BitbucketProvider
+// ============================================================================
+
+// BitbucketProvider is the RepoProvider of Bitbucket Cloud.
+//
+// Bitbucket has no stars. Since watching is the only way to bookmark there,
+// the number of the watchers is set to the Stars instead of the Followers. It
+// costs 3 requests. Such as the repository, the forks and the watchers.
+type BitbucketProvider struct {
+	BaseURL string // Base URL of Bitbucket API 2.0. Such as "https://api.bitbucket.org/2.0"
+}
+
+// UpdateRepo is an implementation of RepoProvider.
+func (p *BitbucketProvider) UpdateRepo(ctx context.Context, client *Client, r *RepoInfo) error {
+	var (
+		repo struct {
+			Description string    `json:"description"`
+			UpdatedOn   time.Time `json:"updated_on"`
+		}
+		forks, watchers struct {
+			Size int `json:"size"`
+		}
+	)
+
+	urlAPI := strings.TrimSuffix(p.BaseURL, "/") + "/repositories/" + r.Owner + "/" + r.Name
+
+	cachedRepo, err := client.getJSON(ctx, urlAPI, &repo)
+	if err != nil {
+		return errors.Wrap(err, "faild to get repository info from Bitbucket")
+	}
+
+	// The size of the paginated list is the total count
+	cachedForks, err := client.getJSON(ctx, urlAPI+"/forks?pagelen=1", &forks)
+	if err != nil {
+		return errors.Wrap(err, "faild to get forks from Bitbucket")
+	}
+
+	cachedWatchers, err := client.getJSON(ctx, urlAPI+"/watchers?pagelen=1", &watchers)
+	if err != nil {
+		return errors.Wrap(err, "faild to get watchers from Bitbucket")
+	}
+
+	r.Description = repo.Description
+	r.Stars = watchers.Size
+	r.Forks = forks.Size
+	r.LastPush = repo.UpdatedOn
+	r.cached = cachedRepo && cachedForks && cachedWatchers
+
+	return nil
+}
+
+// ============================================================================
+//  Type: GiteaProvider
+// ============================================================================
+
+// GiteaProvider is the RepoProvider of Gitea and Forgejo. Such as Codeberg and
+// the self-hosted ones.
+type GiteaProvider struct {
+	BaseURL string // Base URL of Gitea API v1. Such as "https://codeberg.org/api/v1"
+}
+
+// UpdateRepo is an implementation of RepoProvider.
+func (p *GiteaProvider) UpdateRepo(ctx context.Context, client *Client, r *RepoInfo) error {
+	var repo struct {
+		Description   string    `json:"description"`
+		StarsCount    int       `json:"stars_count"`
+		ForksCount    int       `json:"forks_count"`
+		WatchersCount int       `json:"watchers_count"`
+		UpdatedAt     time.Time `json:"updated_at"`
+	}
+
+	urlAPI := strings.TrimSuffix(p.BaseURL, "/") + "/repos/" + r.Owner + "/" + r.Name
+
+	cached, err := client.getJSON(ctx, urlAPI, &repo)
+	if err != nil {
+		return errors.Wrap(err, "faild to get repository info from Gitea")
+	}
+
+	r.Description = repo.Description
+	r.Stars = repo.StarsCount
+	r.Forks = repo.ForksCount
+	r.Followers = repo.WatchersCount
+	r.LastPush = repo.UpdatedAt
+	r.cached = cached
+
+	return nil
+}
+
+// ============================================================================
+//  Private Methods of Client
+// ============================================================================
+
+// getJSON requests the URL via GetContentURLContext and decodes the JSON
+// response to v. It returns true if the response came from the cache.
+func (c *Client) getJSON(ctx context.Context, urlTarget string, v interface{}) (bool, error) {
+	content, cached, err := c.getContent(ctx, urlTarget)
+	if err != nil {
+		return false, err
+	}
+
+	return cached, errors.Wrap(json.Unmarshal(content, v), "malformed response")
+}
+
+// getRepoProvider returns the RepoProvider of the host. The GitHub one is
+// returned for the empty host to be compatible with the RepoInfo created
+// without URL.
+func (c *Client) getRepoProvider(host string) (RepoProvider, bool) {
+	providers := c.Providers
+	if providers == nil {
+		providers = DefaultRepoProviders()
+	}
+
+	if host == "" {
+		return &GitHubProvider{}, true
+	}
+
+	provider, ok := providers[strings.ToLower(host)]
+
+	return provider, ok && provider != nil
+}
@@ -0,0 +1,145 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  RepoProvider
+// ----------------------------------------------------------------------------
+
+// addProviderHandlers adds the mocks of the APIs of GitLab, Bitbucket and Gitea
+// which serve "KEINOS/dev-go". GitLab also serves it in a nested group.
+func addProviderHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/gitlab/projects/", func(w http.ResponseWriter, r *http.Request) {
+		// The path of the project should be URL-encoded as a single segment
+		switch r.URL.EscapedPath() {
+		case "/gitlab/projects/KEINOS%2Fdev-go", "/gitlab/projects/group%2Fsub%2Fdev-go":
+		default:
+			http.NotFound(w, r)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"description": "on GitLab", "star_count": 20, "forks_count": 4,
+"last_activity_at": "2022-01-02T03:04:05.123Z"}`)
+	})
+
+	mux.HandleFunc("/bitbucket/repositories/KEINOS/dev-go", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"description": "on Bitbucket", "updated_on": "2022-01-02T03:04:05.123456+00:00"}`)
+	})
+
+	mux.HandleFunc("/bitbucket/repositories/KEINOS/dev-go/forks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"size": 5, "pagelen": 1, "values": [{}]}`)
+	})
+
+	mux.HandleFunc("/bitbucket/repositories/KEINOS/dev-go/watchers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"size": 30, "pagelen": 1, "values": [{}]}`)
+	})
+
+	mux.HandleFunc("/gitea/repos/KEINOS/dev-go", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"description": "on Codeberg", "stars_count": 40, "forks_count": 6, "watchers_count": 7,
+"updated_at": "2022-01-02T03:04:05Z"}`)
+	})
+}
+
+func TestClient_NewRepoInfo_providers(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+	lastPush := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, test := range []struct {
+		url       string
+		expect    gostars.RepoInfo
+		truncated time.Duration
+	}{
+		{
+			url:    "https://gitlab.com/KEINOS/dev-go",
+			expect: gostars.RepoInfo{Description: "on GitLab", Stars: 20, Forks: 4},
+		},
+		{
+			url:    "https://bitbucket.org/KEINOS/dev-go",
+			expect: gostars.RepoInfo{Description: "on Bitbucket", Stars: 30, Forks: 5},
+		},
+		{
+			url:    "https://codeberg.org/KEINOS/dev-go",
+			expect: gostars.RepoInfo{Description: "on Codeberg", Stars: 40, Forks: 6, Followers: 7},
+		},
+	} {
+		repoInfo, err := client.NewRepoInfo(test.url)
+		require.NoError(t, err, test.url)
+
+		assert.Equal(t, "KEINOS", repoInfo.Owner)
+		assert.Equal(t, "dev-go", repoInfo.Name)
+		assert.Equal(t, test.expect.Description, repoInfo.Description)
+		assert.Equal(t, test.expect.Stars, repoInfo.Stars, test.url)
+		assert.Equal(t, test.expect.Forks, repoInfo.Forks, test.url)
+		assert.Equal(t, test.expect.Followers, repoInfo.Followers, test.url)
+		assert.Equal(t, lastPush, repoInfo.LastPush.UTC().Truncate(time.Second), test.url)
+	}
+
+	// Project in the nested groups of GitLab
+	repoInfo, err := client.NewRepoInfo("https://gitlab.com/group/sub/dev-go/-/tree/main/cmd")
+	require.NoError(t, err)
+
+	assert.Equal(t, "group/sub", repoInfo.Owner)
+	assert.Equal(t, "dev-go", repoInfo.Name)
+	assert.Equal(t, 20, repoInfo.Stars)
+
+	// Unknown repository on the supported host
+	_, err = client.NewRepoInfo("https://gitlab.com/foo/bar")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "faild to get repository info from GitLab")
+
+	// Self-hosted one
+	_, err = client.NewRepoInfo("https://git.example.com/KEINOS/dev-go")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported host of the repository: "git.example.com"`)
+
+	client.Providers["git.example.com"] = client.Providers["codeberg.org"]
+
+	repoInfo, err = client.NewRepoInfo("https://git.example.com/KEINOS/dev-go")
+	require.NoError(t, err)
+	assert.Equal(t, 40, repoInfo.Stars)
+
+	// The activity is GitHub only
+	err = repoInfo.UpdateActivity()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "activity is available only for GitHub repositories")
+}
+
+func TestClient_ScorePackage_not_github(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/pkg/") {
+			fmt.Fprint(w, `<div class="UnitMeta-repo"><a href="https://codeberg.org/KEINOS/dev-go">codeberg.org/KEINOS/dev-go</a></div>`)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"stars_count": 40, "forks_count": 6, "watchers_count": 7}`)
+	}))
+	defer server.Close()
+
+	client := newFakeClient(server)
+	client.Activity = true
+	client.Health = true
+
+	score, err := client.ScorePackage(context.Background(), "codeberg.org/KEINOS/dev-go")
+	require.NoError(t, err, "the activity and health should not fail the score")
+
+	assert.Equal(t, 40, score.Repo.Stars)
+	assert.Nil(t, score.Repo.Activity)
+	assert.Equal(t, []string{
+		"activity is available only for GitHub repositories",
+		"health is available only for GitHub repositories",
+	}, score.Warnings)
+}
@@ -0,0 +1,85 @@
+package gostars
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: ReportCardInfo
+// ============================================================================
+
+// ReportCardInfo holds the result of Go Report Card (goreportcard.com) of a
+// repository.
+type ReportCardInfo struct {
+	Repo    string            `json:"repo"`    // Repository checked. Such as "github.com/KEINOS/gostars"
+	Grade   string            `json:"grade"`   // Grade of the repository. Such as "A+"
+	Average float64           `json:"average"` // Weighted average of the checks. 0..1
+	Files   int               `json:"files"`   // Number of the files checked
+	Issues  int               `json:"issues"`  // Number of the issues found
+	Checks  []ReportCardCheck `json:"checks"`  // Result per linter
+}
+
+// ReportCardCheck is the result of a linter of Go Report Card.
+type ReportCardCheck struct {
+	Name       string  `json:"name"`       // Name of the linter. Such as "gofmt"
+	Percentage float64 `json:"percentage"` // Ratio of the files passed the check. 0..1
+	Weight     float64 `json:"weight"`     // Weight of the check in the average
+}
+
+// ============================================================================
+//  Constructor
+// ============================================================================
+
+// NewReportCardInfo returns the Go Report Card result of the repository. It is
+// a wrapper of DefaultClient.NewReportCardInfo.
+func NewReportCardInfo(ctx context.Context, nameRepo string) (*ReportCardInfo, error) {
+	return DefaultClient.NewReportCardInfo(ctx, nameRepo)
+}
+
+// NewReportCardInfo returns the Go Report Card result of the repository from
+// ReportCardBaseURL. The name of the repository can be the URL. Such as
+// "github.com/KEINOS/gostars" and "https://github.com/KEINOS/gostars".
+//
+// The request is sent via GetContentURLContext. Thus it is throttled and cached
+// as the other requests of the client.
+func (c *Client) NewReportCardInfo(ctx context.Context, nameRepo string) (*ReportCardInfo, error) {
+	nameRepo = strings.TrimPrefix(strings.TrimPrefix(nameRepo, "https://"), "http://")
+	urlBase := strings.TrimSuffix(c.getReportCardBaseURL(), "/")
+
+	content, err := c.GetContentURLContext(ctx, urlBase+"/checks?repo="+url.QueryEscape(nameRepo))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get Go Report Card")
+	}
+
+	info := new(ReportCardInfo)
+
+	if err := json.Unmarshal(content, info); err != nil {
+		return nil, errors.Wrap(err, "malformed response of Go Report Card")
+	}
+
+	if info.Grade == "" {
+		return nil, errors.Errorf("no Go Report Card for %s", nameRepo)
+	}
+
+	return info, nil
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// GetCheck returns the result of the linter. It returns false if not found.
+func (r *ReportCardInfo) GetCheck(name string) (ReportCardCheck, bool) {
+	for _, check := range r.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+
+	return ReportCardCheck{}, false
+}
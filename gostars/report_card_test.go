@@ -0,0 +1,97 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  ReportCardInfo
+// ----------------------------------------------------------------------------
+
+// addReportCardHandlers adds the mock of Go Report Card which knows
+// "github.com/KEINOS/dev-go" only.
+func addReportCardHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/reportcard/checks", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("repo") != "github.com/KEINOS/dev-go" {
+			fmt.Fprint(w, `{"error": "unknown repository"}`)
+
+			return
+		}
+
+		fmt.Fprint(w, `{
+"repo": "github.com/KEINOS/dev-go",
+"grade": "A+",
+"average": 0.95,
+"files": 10,
+"issues": 1,
+"checks": [
+	{"name": "gofmt", "percentage": 1, "weight": 0.3},
+	{"name": "golint", "percentage": 0.9, "weight": 0.1}
+]}`)
+	})
+}
+
+func TestClient_NewReportCardInfo(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	info, err := client.NewReportCardInfo(context.Background(), "https://github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, "A+", info.Grade)
+	assert.Equal(t, 0.95, info.Average)
+	assert.Equal(t, 1, info.Issues)
+
+	check, ok := info.GetCheck("golint")
+	require.True(t, ok)
+	assert.Equal(t, 0.9, check.Percentage)
+
+	_, ok = info.GetCheck("unknown")
+	assert.False(t, ok)
+
+	// Unknown repository
+	_, err = client.NewReportCardInfo(context.Background(), "github.com/foo/bar")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no Go Report Card for github.com/foo/bar")
+
+	// Malformed response
+	client.ReportCardBaseURL = server.URL + "/content/hello.txt?"
+
+	_, err = client.NewReportCardInfo(context.Background(), "github.com/KEINOS/dev-go")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "malformed response of Go Report Card")
+}
+
+func TestClient_ScorePackage_report_card(t *testing.T) {
+	server := newFakeServer(t)
+	client := newFakeClient(server)
+
+	client.GravityModel = &gostars.GravityModel{
+		Weights: map[string]float64{gostars.DimReportCard: 1},
+	}
+
+	score, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	require.NotNil(t, score.ReportCard)
+	assert.Equal(t, 95, score.Gravity)
+	assert.Empty(t, score.Warnings)
+
+	// Failing to get the report card does not fail the score
+	client.ReportCardBaseURL = server.URL + "/unknown"
+
+	score, err = client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Nil(t, score.ReportCard)
+	assert.Zero(t, score.Gravity)
+	require.Len(t, score.Warnings, 1)
+	assert.Contains(t, score.Warnings[0], "failed to get Go Report Card")
+}
@@ -0,0 +1,182 @@
+package gostars
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: Score
+// ============================================================================
+
+// Score holds the package and repository information of a package with its
+// attraction gravity.
+type Score struct {
+	Name       string            `json:"name"`                  // Name of the package given to score
+	Pkg        *PkgInfo          `json:"package"`               // Package info from pkg.go.dev. Nil on error
+	Repo       *RepoInfo         `json:"repository"`            // Repository info from GitHub. Nil on error
+	Level      string            `json:"level"`                 // Level of the importers for the gravity. See PkgInfo.GetImportedBy
+	Gravity    int               `json:"gravity"`               // Attraction gravity of the package
+	Breakdown  *GravityBreakdown `json:"breakdown,omitempty"`   // Detail of the calculation of Gravity. Nil on error
+	ReportCard *ReportCardInfo   `json:"report_card,omitempty"` // Go Report Card. Nil if not fetched or failed
+	Coverage   *CoverageInfo     `json:"coverage,omitempty"`    // Code coverage rate. Nil if not fetched or not found
+	Warnings   []string          `json:"warnings,omitempty"`    // Errors of the optional services that did not fail the score
+	Err        error             `json:"-"`                     // Error occurred while scoring. Nil on success
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// ScorePackage returns the Score of the package. It is a wrapper of
+// DefaultClient.ScorePackage.
+func ScorePackage(ctx context.Context, namePkg string) (*Score, error) {
+	return DefaultClient.ScorePackage(ctx, namePkg)
+}
+
+// ScoreAll returns the Score of each package in the same order as namePkgs.
+// It is a wrapper of DefaultClient.ScoreAll.
+func ScoreAll(ctx context.Context, namePkgs []string) []*Score {
+	return DefaultClient.ScoreAll(ctx, namePkgs)
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// ScorePackage fetches the package and repository information of the package
+// and returns the Score. The gravity is calculated with the GravityModel of the
+// client.
+//
+// The activity of the repository is also fetched if Activity of the client is
+// true or the model weights any of the activity dimensions. So as the Go Report
+// Card with ReportCard, the code coverage with Coverage and the health of the
+// repository with Health. Failing to get them does not fail the score but is
+// recorded in Warnings. Except the activity that the model weights, without
+// which the gravity can not be calculated. The activity and the health of the
+// repositories not on GitHub are not supported and recorded in Warnings too.
+//
+// The importers of the package, its module or its repository root are used for
+// the gravity by the Level of the client. With NormMinMax or NormZScore, the
+// single package is the center of the range. See GravityModel.
+func (c *Client) ScorePackage(ctx context.Context, namePkg string) (*Score, error) {
+	level := c.getLevel()
+	if !isLevel(level) {
+		return nil, errors.Errorf("unknown level: %q", level)
+	}
+
+	pkgInfo, err := c.NewPkgInfoContext(ctx, namePkg)
+	if err != nil {
+		return nil, err
+	}
+
+	repoInfo, err := c.NewRepoInfoContext(ctx, pkgInfo.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	model := c.getGravityModel()
+	score := &Score{
+		Name:  namePkg,
+		Pkg:   pkgInfo,
+		Repo:  repoInfo,
+		Level: level,
+	}
+
+	// The activity costs more requests. Fetch it only if needed
+	if needsActivity := model.needs(activityDimensions...); c.Activity || needsActivity {
+		if !repoInfo.isGitHub() {
+			score.Warnings = append(score.Warnings, "activity is available only for GitHub repositories")
+		} else if err := repoInfo.UpdateActivityContext(ctx); err != nil {
+			// Such as "422 Unprocessable Entity" of the repositories with 10k+ commits
+			if needsActivity || ctx.Err() != nil {
+				return nil, err
+			}
+
+			score.Warnings = append(score.Warnings, errors.Wrap(err, "failed to get activity").Error())
+		}
+	}
+
+	// The health is not a dimension. Fetch it only if requested
+	if c.Health {
+		if !repoInfo.isGitHub() {
+			score.Warnings = append(score.Warnings, "health is available only for GitHub repositories")
+		} else if err := repoInfo.UpdateHealthContext(ctx); err != nil {
+			score.Warnings = append(score.Warnings, errors.Wrap(err, "failed to get health").Error())
+		}
+	}
+
+	if c.ReportCard || model.needs(DimReportCard) {
+		reportCard, err := c.NewReportCardInfo(ctx, pkgInfo.Repository)
+		if err != nil {
+			score.Warnings = append(score.Warnings, err.Error())
+		}
+
+		score.ReportCard = reportCard
+	}
+
+	if c.Coverage || model.needs(DimCoverage) {
+		coverage, err := c.NewCoverageInfo(ctx, repoInfo.URL.String())
+		if err != nil {
+			score.Warnings = append(score.Warnings, err.Error())
+		}
+
+		score.Coverage = coverage
+	}
+
+	// A batch of one. ScoreAll applies the model again among all the packages
+	model.Apply([]*Score{score})
+
+	return score, nil
+}
+
+// ScoreAll scores the packages concurrently with the number of workers set in
+// Concurrency of the client.
+//
+// The returned slice is in the same order as namePkgs. The packages failed to
+// score have the error in the Err field instead of aborting the others. All the
+// requests are throttled by the RateLimiter of the client.
+//
+// The gravity and its breakdown are recalculated with the GravityModel of the
+// client among the scored packages. See GravityModel for the normalizations.
+func (c *Client) ScoreAll(ctx context.Context, namePkgs []string) []*Score {
+	results := make([]*Score, len(namePkgs))
+	jobs := make(chan int)
+
+	numWorkers := c.Concurrency
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range jobs {
+				score, err := c.ScorePackage(ctx, namePkgs[index])
+				if err != nil {
+					score = &Score{Name: namePkgs[index], Err: err}
+				}
+
+				results[index] = score
+			}
+		}()
+	}
+
+	for index := range namePkgs {
+		jobs <- index
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	c.getGravityModel().Apply(results)
+
+	return results
+}
@@ -0,0 +1,161 @@
+package gostars_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Score
+// ----------------------------------------------------------------------------
+
+func TestClient_ScorePackage(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	score, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, "github.com/KEINOS/dev-go", score.Name)
+	assert.Equal(t, 2, score.Pkg.ImportedBy)
+	assert.Equal(t, 10, score.Repo.Stars)
+	assert.Equal(t, gostars.GetAttractionGravity(10, 3, 2, 2), score.Gravity)
+	assert.NoError(t, score.Err)
+
+	// Custom model
+	client.GravityModel = &gostars.GravityModel{
+		Weights: map[string]float64{gostars.DimStars: 3},
+	}
+
+	score, err = client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go")
+	require.NoError(t, err)
+
+	assert.Equal(t, 30, score.Gravity)
+}
+
+func TestClient_ScorePackage_level(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+	client.GravityModel = &gostars.GravityModel{
+		Weights: map[string]float64{gostars.DimImportedBy: 1},
+	}
+
+	for level, expect := range map[string]int{
+		"":                   1,
+		gostars.LevelPackage: 1,
+		gostars.LevelModule:  5,
+		gostars.LevelRepo:    2,
+	} {
+		client.Level = level
+
+		score, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go/tools/lint")
+		require.NoError(t, err, level)
+
+		assert.Equal(t, expect, score.Gravity, level)
+		assert.NotEmpty(t, score.Level)
+	}
+
+	client.Level = "unknown"
+
+	_, err := client.ScorePackage(context.Background(), "github.com/KEINOS/dev-go/tools/lint")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown level: "unknown"`)
+}
+
+func TestClient_ScoreAll(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	results := client.ScoreAll(context.Background(), []string{
+		"github.com/KEINOS/dev-go",
+		"github.com/KEINOS/undefined",
+		"github.com/KEINOS/dev-go",
+	})
+
+	require.Len(t, results, 3)
+
+	for _, index := range []int{0, 2} {
+		require.NoError(t, results[index].Err)
+		assert.Equal(t, "github.com/KEINOS/dev-go", results[index].Name, "results should be in the input order")
+		assert.Equal(t, 10, results[index].Repo.Stars)
+	}
+
+	require.Error(t, results[1].Err, "failed package should have the error")
+	assert.Equal(t, "github.com/KEINOS/undefined", results[1].Name)
+	assert.Nil(t, results[1].Pkg)
+	assert.Nil(t, results[1].Repo)
+}
+
+func TestClient_ScoreAll_concurrency(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		numInFlight int
+		maxInFlight int
+	)
+
+	// Mock server of both pkg.go.dev and GitHub API that serves any package
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		numInFlight++
+		if numInFlight > maxInFlight {
+			maxInFlight = numInFlight
+		}
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			numInFlight--
+			mu.Unlock()
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			fmt.Fprint(w, `{"stargazers_count": 1}`)
+
+			return
+		}
+
+		fmt.Fprintf(w, `<div class="UnitMeta-repo"><a>%s</a></div>`, strings.TrimPrefix(r.URL.Path, "/pkg/"))
+	}))
+	defer server.Close()
+
+	client := newFakeClient(server)
+	client.Concurrency = 2
+
+	namePkgs := []string{}
+	for i := 0; i < 6; i++ {
+		namePkgs = append(namePkgs, fmt.Sprintf("github.com/foo/bar%d", i))
+	}
+
+	results := client.ScoreAll(context.Background(), namePkgs)
+
+	for index, result := range results {
+		require.NoError(t, result.Err)
+		assert.Equal(t, namePkgs[index], result.Name)
+		assert.Equal(t, namePkgs[index], "github.com/"+result.Repo.Owner+"/"+result.Repo.Name)
+	}
+
+	assert.LessOrEqual(t, maxInFlight, 2, "number of requests at once should not exceed the concurrency")
+	assert.Greater(t, maxInFlight, 1, "packages should be scored concurrently")
+}
+
+func TestClient_ScoreAll_canceled(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := client.ScoreAll(ctx, []string{"github.com/KEINOS/dev-go", "github.com/KEINOS/dev-go"})
+
+	for _, result := range results {
+		require.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
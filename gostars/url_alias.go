@@ -0,0 +1,200 @@
+package gostars
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Match types of URLAlias.
+const (
+	MatchExact  = "exact"  // The URL equals to From
+	MatchPrefix = "prefix" // The URL starts with From. The prefix is replaced with To
+	MatchRegex  = "regex"  // The URL matches the regular expression From. To can refer to the groups. Such as "$1"
+)
+
+// ============================================================================
+//  Type: URLAlias
+// ============================================================================
+
+// URLAlias maps the URL of a site to the actual URL of the repository.
+type URLAlias struct {
+	From  string `json:"from" yaml:"from"`                       // URL, prefix or regular expression to match
+	To    string `json:"to" yaml:"to"`                           // URL of the repository or its replacement
+	Match string `json:"match,omitempty" yaml:"match,omitempty"` // Match type. "exact" (default), "prefix" or "regex"
+}
+
+// ============================================================================
+//  Type: AliasRegistry
+// ============================================================================
+
+// AliasRegistry is an ordered list of URLAlias. The first matching alias is
+// used to resolve a URL.
+type AliasRegistry struct {
+	Aliases []URLAlias `json:"aliases" yaml:"aliases"` // Aliases in the order of priority
+
+	regexes map[int]*regexp.Regexp // Compiled From of the regex aliases per index
+}
+
+// ============================================================================
+//  Constructor
+// ============================================================================
+
+// NewAliasRegistry returns a new AliasRegistry of the aliases. It returns an
+// error if an alias is invalid. Such as an unknown match type or a malformed
+// regular expression.
+func NewAliasRegistry(aliases ...URLAlias) (*AliasRegistry, error) {
+	registry := &AliasRegistry{Aliases: append([]URLAlias{}, aliases...)}
+
+	if err := registry.compile(); err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+// DefaultAliasRegistry returns the registry of the built-in aliases. Which are
+// the exact matches of URLAliases.
+func DefaultAliasRegistry() *AliasRegistry {
+	aliases := make([]URLAlias, 0, len(URLAliases))
+
+	for from, to := range URLAliases {
+		aliases = append(aliases, URLAlias{From: from, To: to, Match: MatchExact})
+	}
+
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].From < aliases[j].From })
+
+	// The exact matches never fail to compile
+	registry, _ := NewAliasRegistry(aliases...)
+
+	return registry
+}
+
+// LoadAliasRegistry reads the aliases from the file in YAML or JSON. See
+// ParseAliasRegistry for the format.
+func LoadAliasRegistry(path string) (*AliasRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read aliases")
+	}
+
+	return ParseAliasRegistry(data)
+}
+
+// ParseAliasRegistry parses the aliases in YAML or JSON. Such as:
+//
+//	aliases:
+//	  - from: https://joe-bot.net/
+//	    to: https://github.com/go-joe/joe
+//	  - from: https://go.example.com/
+//	    to: https://github.com/example/
+//	    match: prefix
+//	  - from: ^https://example\.org/([^/]+)
+//	    to: https://gitlab.com/example/$1
+//	    match: regex
+func ParseAliasRegistry(data []byte) (*AliasRegistry, error) {
+	registry := new(AliasRegistry)
+
+	// YAML is a superset of JSON
+	if err := yaml.Unmarshal(data, registry); err != nil {
+		return nil, errors.Wrap(err, "malformed aliases")
+	}
+
+	if err := registry.compile(); err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+// ============================================================================
+//  Methods
+// ============================================================================
+
+// Merge returns a new registry of the aliases of r followed by the ones of
+// others. Thus the aliases of r have priority. Such as the user's aliases
+// merged with DefaultAliasRegistry.
+func (r *AliasRegistry) Merge(others ...*AliasRegistry) *AliasRegistry {
+	aliases := append([]URLAlias{}, r.Aliases...)
+
+	for _, other := range others {
+		if other != nil {
+			aliases = append(aliases, other.Aliases...)
+		}
+	}
+
+	// The aliases are already compiled once
+	merged, _ := NewAliasRegistry(aliases...)
+
+	return merged
+}
+
+// Resolve returns the URL mapped by the first matching alias with the alias.
+// It returns the URL as is and false if no alias matches.
+func (r *AliasRegistry) Resolve(urlOrigin string) (string, URLAlias, bool) {
+	if r == nil {
+		return urlOrigin, URLAlias{}, false
+	}
+
+	for index, alias := range r.Aliases {
+		switch alias.getMatch() {
+		case MatchExact:
+			if urlOrigin == alias.From {
+				return alias.To, alias, true
+			}
+		case MatchPrefix:
+			if strings.HasPrefix(urlOrigin, alias.From) {
+				return alias.To + strings.TrimPrefix(urlOrigin, alias.From), alias, true
+			}
+		case MatchRegex:
+			if re := r.regexes[index]; re != nil && re.MatchString(urlOrigin) {
+				return re.ReplaceAllString(urlOrigin, alias.To), alias, true
+			}
+		}
+	}
+
+	return urlOrigin, URLAlias{}, false
+}
+
+// compile validates the aliases and compiles the regular expressions. The
+// match types are normalized. Such as MatchExact if not set.
+func (r *AliasRegistry) compile() error {
+	r.regexes = map[int]*regexp.Regexp{}
+
+	for index, alias := range r.Aliases {
+		if alias.From == "" {
+			return errors.Errorf("alias #%d: from is empty", index+1)
+		}
+
+		r.Aliases[index].Match = alias.getMatch()
+
+		switch alias.getMatch() {
+		case MatchExact, MatchPrefix:
+		case MatchRegex:
+			re, err := regexp.Compile(alias.From)
+			if err != nil {
+				return errors.Wrapf(err, "alias #%d: malformed regular expression", index+1)
+			}
+
+			r.regexes[index] = re
+		default:
+			return errors.Errorf("alias #%d: unknown match type: %q (available: %s, %s, %s)",
+				index+1, alias.Match, MatchExact, MatchPrefix, MatchRegex)
+		}
+	}
+
+	return nil
+}
+
+// getMatch returns the match type. It is MatchExact if not set.
+func (a URLAlias) getMatch() string {
+	if a.Match == "" {
+		return MatchExact
+	}
+
+	return strings.ToLower(a.Match)
+}
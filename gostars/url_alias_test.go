@@ -0,0 +1,101 @@
+package gostars_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  AliasRegistry
+// ----------------------------------------------------------------------------
+
+func TestParseAliasRegistry(t *testing.T) {
+	registry, err := gostars.ParseAliasRegistry([]byte(`
+aliases:
+  - from: https://go.example.com/
+    to: https://github.com/example/
+    match: prefix
+  - from: ^https://example\.org/([^/]+)(/.*)?$
+    to: https://gitlab.com/example/$1
+    match: regex
+  - from: https://joe-bot.net/
+    to: https://gitlab.com/override/joe
+`))
+	require.NoError(t, err)
+
+	merged := registry.Merge(gostars.DefaultAliasRegistry())
+
+	for _, test := range []struct {
+		input  string
+		expect string
+		match  string
+	}{
+		{"https://go.example.com/foo", "https://github.com/example/foo", gostars.MatchPrefix},
+		{"https://example.org/bar/sub/pkg", "https://gitlab.com/example/bar", gostars.MatchRegex},
+		{"https://joe-bot.net/", "https://gitlab.com/override/joe", gostars.MatchExact},
+		{"https://github.com/KEINOS/dev-go", "https://github.com/KEINOS/dev-go", ""},
+	} {
+		actual, alias, ok := merged.Resolve(test.input)
+
+		assert.Equal(t, test.expect, actual, test.input)
+		assert.Equal(t, test.match != "", ok, test.input)
+
+		if ok {
+			assert.Equal(t, test.match, alias.Match, test.input)
+		}
+	}
+
+	// The built-in alias is used if not overridden
+	actual, _, ok := gostars.DefaultAliasRegistry().Resolve("https://joe-bot.net/")
+	require.True(t, ok)
+	assert.Equal(t, "https://github.com/go-joe/joe", actual)
+
+	// JSON
+	registry, err = gostars.ParseAliasRegistry([]byte(`{"aliases": [{"from": "https://a.example/", "to": "https://github.com/a/a"}]}`))
+	require.NoError(t, err)
+
+	actual, _, ok = registry.Resolve("https://a.example/")
+	require.True(t, ok)
+	assert.Equal(t, "https://github.com/a/a", actual)
+}
+
+func TestParseAliasRegistry_invalid(t *testing.T) {
+	for _, test := range []struct {
+		input   string
+		contain string
+	}{
+		{"aliases: {}", "malformed aliases"},
+		{"aliases: [{to: https://github.com/a/a}]", "alias #1: from is empty"},
+		{"aliases: [{from: a, to: b, match: glob}]", `alias #1: unknown match type: "glob"`},
+		{"aliases: [{from: '(', to: b, match: regex}]", "alias #1: malformed regular expression"},
+	} {
+		_, err := gostars.ParseAliasRegistry([]byte(test.input))
+
+		require.Error(t, err, test.input)
+		assert.Contains(t, err.Error(), test.contain)
+	}
+
+	_, err := gostars.LoadAliasRegistry(filepath.Join(t.TempDir(), "unknown.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read aliases")
+}
+
+func TestClient_NewRepoInfo_aliases(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	registry, err := gostars.NewAliasRegistry(gostars.URLAlias{
+		From: "https://dev-go.example.com/", To: "https://github.com/KEINOS/dev-go", Match: gostars.MatchPrefix,
+	})
+	require.NoError(t, err)
+
+	client.Aliases = registry
+
+	repoInfo, err := client.NewRepoInfo("https://dev-go.example.com/")
+	require.NoError(t, err)
+	assert.Equal(t, "dev-go", repoInfo.Name)
+	assert.Equal(t, 10, repoInfo.Stars)
+}
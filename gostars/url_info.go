@@ -2,21 +2,36 @@ package gostars
 
 import (
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
 )
 
+// reSCPLike matches the SCP-like syntax of the git URLs. Such as
+// "git@github.com:owner/repo.git".
+var reSCPLike = regexp.MustCompile(`^(?:[\w.-]+@)?([\w-]+(?:\.[\w-]+)+):(.+)$`)
+
 // ============================================================================
 //  Type: URLInfo
 // ============================================================================
 
 // URLInfo contains information about the parsed URL.
+//
+// The URL is normalized. The scheme and host are lower-cased and "www." of the
+// host is removed. The path is split into the owner, the repository name and
+// the subdirectory. Such as "https://github.com/owner/repo.git" and
+// "git@github.com:owner/repo" to ["owner", "repo"], and
+// "https://github.com/owner/repo/tree/main/sub" to ["owner", "repo", "sub"].
+//
+// GitLab allows the nested groups. Such as "https://gitlab.com/group/sub/repo".
+// For GitLab, the whole path up to "/-/" is the project. See Project.
 type URLInfo struct {
 	RawURL string   // the original url
-	Scheme string   // protocol
+	Scheme string   // protocol. Such as "https" and "ssh"
 	Host   string   // host or host:port
-	Path   []string // slice of directory path
+	Path   []string // slice of directory path. The owner, the repository and the subdirectory
+	Depth  int      // Number of the elements of the project in Path. 2 (owner and repository) if 0
 }
 
 // ============================================================================
@@ -24,6 +39,10 @@ type URLInfo struct {
 // ============================================================================
 
 // NewURLInfo returns the initialized object of URLInfo from urlTarget.
+//
+// Besides the URLs, it accepts the SCP-like git URLs such as
+// "git@github.com:owner/repo.git" and the URLs without scheme such as
+// "github.com/owner/repo".
 func NewURLInfo(urlTarget string) (*URLInfo, error) {
 	urlInfo := URLInfo{RawURL: urlTarget}
 
@@ -43,14 +62,92 @@ func (u *URLInfo) IsRepoGitHub() bool {
 	return u.Host == "github.com"
 }
 
+// Owner returns the owner of the repository. Such as "KEINOS". For the nested
+// groups of GitLab, it is the full path of the group. Such as "group/sub". It
+// returns an empty string if the path is empty.
+func (u *URLInfo) Owner() string {
+	if len(u.Path) < u.getDepth() {
+		return strings.Join(u.Path, "/")
+	}
+
+	return strings.Join(u.Path[:u.getDepth()-1], "/")
+}
+
+// Repo returns the name of the repository. Such as "gostars". It returns an
+// empty string if the path has no repository name.
+func (u *URLInfo) Repo() string {
+	if len(u.Path) < u.getDepth() {
+		return ""
+	}
+
+	return u.Path[u.getDepth()-1]
+}
+
+// Project returns the full path of the repository. Such as "KEINOS/gostars"
+// and "group/sub/repo" of GitLab. It returns an empty string if the path has
+// no repository name.
+func (u *URLInfo) Project() string {
+	if len(u.Path) < u.getDepth() {
+		return ""
+	}
+
+	return strings.Join(u.Path[:u.getDepth()], "/")
+}
+
+// Subdir returns the subdirectory in the repository. Such as "cmd/gostars". It
+// returns an empty string if the URL is the repository root.
+func (u *URLInfo) Subdir() string {
+	if len(u.Path) <= u.getDepth() {
+		return ""
+	}
+
+	return strings.Join(u.Path[u.getDepth():], "/")
+}
+
+// String is an implementation of Stringer. It returns the canonical URL of the
+// repository. Such as "https://github.com/KEINOS/gostars" for all the forms of
+// the URL. The subdirectory is not included. See Subdir.
+//
+// The scheme is "https" except "http" to support the self-hosted servers. The
+// original URL is RawURL.
+func (u *URLInfo) String() string {
+	if u.Host == "" {
+		return u.RawURL
+	}
+
+	scheme := "https"
+	if u.Scheme == "http" {
+		scheme = "http"
+	}
+
+	path := u.Path
+	if len(path) > u.getDepth() {
+		path = path[:u.getDepth()]
+	}
+
+	return strings.TrimSuffix(scheme+"://"+u.Host+"/"+strings.Join(path, "/"), "/")
+}
+
 func (u *URLInfo) parse() error {
-	parsed, err := url.Parse(u.RawURL)
+	rawURL := strings.TrimSpace(u.RawURL)
+
+	if !strings.Contains(rawURL, "://") {
+		if match := reSCPLike.FindStringSubmatch(rawURL); match != nil {
+			// Such as git@github.com:owner/repo.git
+			rawURL = "ssh://" + match[1] + "/" + strings.TrimPrefix(match[2], "/")
+		} else if first := strings.SplitN(rawURL, "/", 2)[0]; strings.Contains(first, ".") {
+			// Such as github.com/owner/repo
+			rawURL = "https://" + rawURL
+		}
+	}
+
+	parsed, err := url.Parse(rawURL)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse given URL")
 	}
 
-	u.Scheme = parsed.Scheme
-	u.Host = parsed.Host
+	u.Scheme = strings.ToLower(parsed.Scheme)
+	u.Host = strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
 
 	pathChunk := strings.Split(parsed.Path, "/")
 
@@ -63,12 +160,87 @@ func (u *URLInfo) parse() error {
 		}
 	}
 
-	u.Path = pathClean
+	u.Path, u.Depth = cleanRepoPath(pathClean, isNestedHost(u.Host))
 
 	return nil
 }
 
-// String is an implementation of Stringer.
-func (u *URLInfo) String() string {
-	return u.RawURL
+func (u *URLInfo) getDepth() int {
+	if u.Depth < 2 {
+		return 2
+	}
+
+	return u.Depth
+}
+
+// ============================================================================
+//  Private Functions
+// ============================================================================
+
+// cleanRepoPath removes ".git" of the repository name and the path to browse
+// the files of the hosts from the path elements. Such as "tree/<ref>" of
+// GitHub, "-/tree/<ref>" of GitLab, "src/<ref>" of Bitbucket and
+// "src/branch/<ref>" of Gitea. It also returns the number of the elements of
+// the project.
+//
+// If nested is true, such as GitLab, the project is the path up to "-". Since
+// the groups can be nested.
+func cleanRepoPath(path []string, nested bool) ([]string, int) {
+	depth := 2
+
+	if nested {
+		depth = len(path)
+
+		for i, elem := range path {
+			if elem == "-" {
+				depth = i
+
+				break
+			}
+		}
+
+		if depth < 2 {
+			depth = 2
+		}
+	}
+
+	if len(path) < depth {
+		return path, depth
+	}
+
+	path[depth-1] = strings.TrimSuffix(path[depth-1], ".git")
+
+	cleaned := path[:depth]
+	rest := path[depth:]
+
+	if len(rest) > 0 && rest[0] == "-" {
+		rest = rest[1:]
+	}
+
+	skip := 0
+
+	if len(rest) > 0 {
+		switch rest[0] {
+		case "tree", "blob":
+			skip = 2 // tree/<ref>
+		case "src":
+			skip = 2 // src/<ref>
+
+			if len(rest) > 1 && (rest[1] == "branch" || rest[1] == "tag" || rest[1] == "commit") {
+				skip = 3 // src/branch/<ref>
+			}
+		}
+	}
+
+	if skip > len(rest) {
+		skip = len(rest)
+	}
+
+	return append(cleaned, rest[skip:]...), depth
+}
+
+// isNestedHost returns true if the host allows the nested groups in the path of
+// the repositories. Such as gitlab.com and the self-hosted "gitlab.*".
+func isNestedHost(host string) bool {
+	return host == "gitlab.com" || strings.HasPrefix(host, "gitlab.")
 }
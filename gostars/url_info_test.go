@@ -0,0 +1,74 @@
+package gostars_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/gostars/gostars"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  URLInfo
+// ----------------------------------------------------------------------------
+
+func TestNewURLInfo_normalize(t *testing.T) {
+	for _, test := range []struct {
+		input  string
+		scheme string
+		host   string
+		owner  string
+		repo   string
+		subdir string
+		expect string
+	}{
+		{"https://github.com/KEINOS/gostars", "https", "github.com", "KEINOS", "gostars", "", "https://github.com/KEINOS/gostars"},
+		{"https://github.com/KEINOS/gostars.git", "https", "github.com", "KEINOS", "gostars", "", "https://github.com/KEINOS/gostars"},
+		{"git@github.com:KEINOS/gostars.git", "ssh", "github.com", "KEINOS", "gostars", "", "https://github.com/KEINOS/gostars"},
+		{"ssh://git@github.com/KEINOS/gostars.git", "ssh", "github.com", "KEINOS", "gostars", "", "https://github.com/KEINOS/gostars"},
+		{"HTTPS://WWW.GitHub.com/KEINOS/gostars/", "https", "github.com", "KEINOS", "gostars", "", "https://github.com/KEINOS/gostars"},
+		{
+			"http://www.github.com/Owner/Repo/tree/main/cmd/gostars", "http", "github.com", "Owner", "Repo", "cmd/gostars",
+			"http://github.com/Owner/Repo",
+		},
+		{"https://github.com/o/r/blob/v1.0.0/go.mod", "https", "github.com", "o", "r", "go.mod", "https://github.com/o/r"},
+		{"https://gitlab.com/o/r/-/tree/main/sub", "https", "gitlab.com", "o", "r", "sub", "https://gitlab.com/o/r"},
+		{"https://gitlab.com/g/s/r/-/blob/main/sub", "https", "gitlab.com", "g/s", "r", "sub", "https://gitlab.com/g/s/r"},
+		{"git@gitlab.com:g/s/r.git", "ssh", "gitlab.com", "g/s", "r", "", "https://gitlab.com/g/s/r"},
+		{"https://gitlab.example.com/g/s/t/r", "https", "gitlab.example.com", "g/s/t", "r", "", "https://gitlab.example.com/g/s/t/r"},
+		{"https://gitlab.com/g", "https", "gitlab.com", "g", "", "", "https://gitlab.com/g"},
+		{"https://bitbucket.org/o/r/src/master/sub", "https", "bitbucket.org", "o", "r", "sub", "https://bitbucket.org/o/r"},
+		{"https://codeberg.org/o/r/src/branch/main/sub", "https", "codeberg.org", "o", "r", "sub", "https://codeberg.org/o/r"},
+		{"github.com/KEINOS/gostars/gostars", "https", "github.com", "KEINOS", "gostars", "gostars", "https://github.com/KEINOS/gostars"},
+		{"https://git.example.com:8443/o/r", "https", "git.example.com:8443", "o", "r", "", "https://git.example.com:8443/o/r"},
+		{"https://github.com/KEINOS", "https", "github.com", "KEINOS", "", "", "https://github.com/KEINOS"},
+		{"/KEINOS/gostars", "", "", "KEINOS", "gostars", "", "/KEINOS/gostars"},
+	} {
+		urlInfo, err := gostars.NewURLInfo(test.input)
+		require.NoError(t, err, test.input)
+
+		assert.Equal(t, test.input, urlInfo.RawURL)
+		assert.Equal(t, test.scheme, urlInfo.Scheme, test.input)
+		assert.Equal(t, test.host, urlInfo.Host, test.input)
+		assert.Equal(t, test.owner, urlInfo.Owner(), test.input)
+		assert.Equal(t, test.repo, urlInfo.Repo(), test.input)
+		assert.Equal(t, test.subdir, urlInfo.Subdir(), test.input)
+		assert.Equal(t, test.expect, urlInfo.String(), test.input)
+
+		if test.repo != "" {
+			assert.Equal(t, test.owner+"/"+test.repo, urlInfo.Project(), test.input)
+		}
+	}
+}
+
+func TestClient_NewRepoInfo_git_url(t *testing.T) {
+	client := newFakeClient(newFakeServer(t))
+
+	repoInfo, err := client.NewRepoInfo("git@github.com:KEINOS/dev-go.git")
+	require.NoError(t, err)
+
+	assert.Equal(t, "KEINOS", repoInfo.Owner)
+	assert.Equal(t, "dev-go", repoInfo.Name)
+	assert.Equal(t, 10, repoInfo.Stars)
+	assert.Equal(t, "https://github.com/KEINOS/dev-go", repoInfo.URL.String())
+}